@@ -3,16 +3,18 @@ package main
 import (
 	"log"
 	"os"
+	"path/filepath"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/theme"
+	"github.com/andrew-tawfik/outreach-routing/internal/history"
 	"github.com/andrew-tawfik/outreach-routing/internal/ui"
 )
 
 func main() {
-	a := app.New()
+	a := app.NewWithID("com.anbaabraam.outreachrouting")
 	a.Settings().SetTheme(theme.DarkTheme())
 	cfg := &ui.Config{
 		App:        a,
@@ -21,6 +23,14 @@ func main() {
 		MainWindow: a.NewWindow("Anba Abraam Service"),
 	}
 
+	store, err := history.Open(filepath.Join(a.Storage().RootURI().Path(), "history.db"))
+	if err != nil {
+		cfg.ErrorLog.Println(err)
+	} else {
+		defer store.Close()
+		cfg.History = store
+	}
+
 	cfg.VehicleSection = container.NewStack()
 	cfg.MainWindow.Resize(fyne.NewSize(1200, 700))
 	cfg.MainWindow.SetMaster()
@@ -4,7 +4,9 @@ go 1.24.0
 
 require (
 	fyne.io/fyne/v2 v2.6.2
+	github.com/go-pdf/fpdf v0.9.0
 	github.com/mroth/weightedrand v1.0.0
+	go.etcd.io/bbolt v1.4.3
 	golang.org/x/oauth2 v0.28.0
 	gopkg.in/Iwark/spreadsheet.v2 v2.0.0-20230915040305-7677e8164883
 )
@@ -56,6 +56,7 @@ func (cw *ClarkeWright) addToSavingsList(first, second int, value float64) {
 
 func (cw *ClarkeWright) StartRouteDispatch(rm *RouteManager, lr *LocationRegistry) error {
 	cw.InitSavings(lr)
+	rm.reserveFullVehicles()
 
 	for cw.savingList.Len() > 0 {
 		saving := heap.Pop(&cw.savingList).(saving)
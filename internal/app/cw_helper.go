@@ -1,6 +1,10 @@
 package app
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+)
 
 
 func (r *Route) isExternal(locationIndex int) (string, bool) {
@@ -118,10 +122,14 @@ func (rm *RouteManager) enoughSeatsToExtend(vehicleIndex, newLocation int) bool
 	return v.SeatsRemaining >= guestsAtLocation && underThreeStops
 }
 
-func (v *Vehicle) findGuests(addresses []string, e *Event, lr *LocationRegistry) {
+func (v *Vehicle) findGuests(addresses []string, e *Event, lr *LocationRegistry, rest map[coordinates.GuestCoordinates][]Guest) {
 	var guestsInvolved []Guest
 	for _, addr := range addresses {
 		coord := lr.CoordianteMap.CoordinateToAddress[addr]
+		if guests, ok := rest[coord]; ok {
+			guestsInvolved = append(guestsInvolved, guests...)
+			continue
+		}
 		for _, g := range e.Guests {
 			if g.Coordinates.Long == coord.Long && g.Coordinates.Lat == coord.Lat {
 				guestsInvolved = append(guestsInvolved, g)
@@ -17,22 +17,33 @@ func (rm *RouteManager) Display(e *Event, lr *LocationRegistry) string {
 		b.WriteString(vehicleInfo)
 		b.WriteString("\n")
 	}
+	if len(rm.Unassigned) > 0 {
+		b.WriteString("Unassigned:\n")
+		var v Vehicle
+		for _, guest := range rm.Unassigned {
+			b.WriteString(v.formatGuestEntry(guest))
+		}
+	}
 	return b.String()
 }
 
 func (v *Vehicle) GetVehicleRouteInfo(index int, e *Event, lr *LocationRegistry) string {
 	if v.Route.List == nil || len(v.Guests) == 0 {
-		return fmt.Sprintf("Driver %d: No guests assigned", index+1)
+		return fmt.Sprintf("%s: No guests assigned", v.DriverName(index))
 	}
 
 	var result strings.Builder
-	result.WriteString(fmt.Sprintf("Driver %d:\n", index+1))
+	result.WriteString(fmt.Sprintf("%s:\n", v.DriverName(index)))
 
 	
 	for _, guest := range v.Guests {
 		result.WriteString(v.formatGuestEntry(guest))
 	}
 
+	for _, link := range v.DirectionsURLs(lr.Depot) {
+		result.WriteString(fmt.Sprintf("  Directions: %s\n", link))
+	}
+
 	return result.String()
 }
 
@@ -41,12 +52,17 @@ func (v *Vehicle) formatGuestEntry(guest Guest) string {
 
 	
 	guestName := guest.Name
-	if guest.GroupSize > 1 {
+	if guest.PartyOf > 0 {
+		guestName = fmt.Sprintf("%s (%s)", guest.Name, guest.SizeLabel())
+	} else if guest.GroupSize > 1 {
 		guestName = fmt.Sprintf("%s (Group of %d)", guest.Name, guest.GroupSize)
 	}
 
 	entry.WriteString(fmt.Sprintf("• %s\n", guestName))
-	entry.WriteString(fmt.Sprintf("    ‣ %s\n", guest.Address))
+	entry.WriteString(fmt.Sprintf("    ‣ %s\n", guest.FullAddress()))
+	if guest.Notes != "" {
+		entry.WriteString(fmt.Sprintf("    ‣ Note: %s\n", guest.Notes))
+	}
 
 	
 	if guest.PhoneNumber != "" {
@@ -54,6 +70,7 @@ func (v *Vehicle) formatGuestEntry(guest Guest) string {
 	} else {
 		entry.WriteString("  No number\n")
 	}
+	entry.WriteString(fmt.Sprintf("    ‣ Waze: %s\n", WazeURL(guest.Coordinates)))
 
 	return entry.String()
 }
@@ -102,4 +119,35 @@ func (v *Vehicle) UpdateRouteFromGuests(lr *LocationRegistry, eventType string)
 			}
 		}
 	}
-}
\ No newline at end of file
+}
+
+// StopNumbers returns the 1-based stop of each guest in v.Guests. Guests
+// living at the same location share a stop.
+func (v *Vehicle) StopNumbers() []int {
+	stops := make([]int, len(v.Guests))
+	seen := make(map[coordinates.GuestCoordinates]int)
+	for i, g := range v.Guests {
+		stop, ok := seen[g.Coordinates]
+		if !ok {
+			stop = len(seen) + 1
+			seen[g.Coordinates] = stop
+		}
+		stops[i] = stop
+	}
+	return stops
+}
+
+// DriverLabel is how the vehicle at index is referred to when it has no
+// driver assigned.
+func DriverLabel(index int) string {
+	return fmt.Sprintf("Driver %d", index+1)
+}
+
+// DriverName returns the assigned driver's name, or the default label for
+// the vehicle at index.
+func (v *Vehicle) DriverName(index int) string {
+	if v.Driver != "" {
+		return v.Driver
+	}
+	return DriverLabel(index)
+}
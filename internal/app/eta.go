@@ -0,0 +1,141 @@
+package app
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+)
+
+// roadFactor scales straight-line distance to an estimated road distance
+// for legs missing from the distance matrix.
+const roadFactor = 1.3
+
+// ETAOptions describes how a vehicle's arrival times are estimated: it
+// leaves the depot at Departure, drives at SpeedKmh and spends Dwell at each
+// stop. Window is the length of the arrival window given to guests.
+type ETAOptions struct {
+	Departure time.Time
+	SpeedKmh  float64
+	Dwell     time.Duration
+	Window    time.Duration
+}
+
+// DefaultETAOptions returns city driving estimates for a departure time.
+func DefaultETAOptions(departure time.Time) ETAOptions {
+	return ETAOptions{
+		Departure: departure,
+		SpeedKmh:  35,
+		Dwell:     5 * time.Minute,
+		Window:    30 * time.Minute,
+	}
+}
+
+// StopArrivals estimates the arrival time at each of the vehicle's
+// StopLocations.
+func (v *Vehicle) StopArrivals(lr *LocationRegistry, opts ETAOptions) []time.Time {
+	stops := v.StopLocations()
+	arrivals := make([]time.Time, len(stops))
+
+	at := opts.Departure
+	from := lr.Depot
+	for i, to := range stops {
+		at = at.Add(lr.travelTime(from, to, opts.SpeedKmh))
+		arrivals[i] = at
+		at = at.Add(opts.Dwell)
+		from = to
+	}
+	return arrivals
+}
+
+// GuestArrivals returns the estimated arrival time for each guest of the
+// vehicle, in the order of v.Guests.
+func (v *Vehicle) GuestArrivals(lr *LocationRegistry, opts ETAOptions) []time.Time {
+	stops := v.StopLocations()
+	arrivals := v.StopArrivals(lr, opts)
+
+	byStop := make(map[coordinates.GuestCoordinates]time.Time, len(stops))
+	for i, c := range stops {
+		byStop[c] = arrivals[i]
+	}
+
+	guestArrivals := make([]time.Time, len(v.Guests))
+	for i, g := range v.Guests {
+		guestArrivals[i] = byStop[g.Coordinates]
+	}
+	return guestArrivals
+}
+
+// travelTime estimates the driving time between two locations at speedKmh.
+func (lr *LocationRegistry) travelTime(from, to coordinates.GuestCoordinates, speedKmh float64) time.Duration {
+	if speedKmh <= 0 {
+		return 0
+	}
+	return time.Duration(lr.legKm(from, to) / speedKmh * float64(time.Hour))
+}
+
+// legKm is the road distance between two locations from the distance
+// matrix, falling back to the straight-line distance for locations the
+// matrix does not cover.
+func (lr *LocationRegistry) legKm(from, to coordinates.GuestCoordinates) float64 {
+	if from == to {
+		return 0
+	}
+
+	km := math.Inf(1)
+	i, j := lr.matrixIndex(from), lr.matrixIndex(to)
+	if i >= 0 && j >= 0 {
+		km = lr.distance(i, j) / 1000
+	}
+	if math.IsInf(km, 1) {
+		km = from.DistanceKm(to) * roadFactor
+	}
+	return km
+}
+
+// RouteDistanceKm is the driving distance from the depot through the
+// vehicle's stops in order.
+func (v *Vehicle) RouteDistanceKm(lr *LocationRegistry) float64 {
+	total := 0.0
+	from := lr.Depot
+	for _, to := range v.StopLocations() {
+		total += lr.legKm(from, to)
+		from = to
+	}
+	return total
+}
+
+// matrixIndex returns the distance matrix index of coord, 0 for the depot.
+func (lr *LocationRegistry) matrixIndex(coord coordinates.GuestCoordinates) int {
+	if coord == lr.Depot {
+		return 0
+	}
+	return lr.AddressIndex(coord)
+}
+
+// optOutColumns are the sheet columns where a guest can decline text
+// messages by marking yes, y, true, 1 or x.
+var optOutColumns = []string{"opt out", "opt-out", "text opt out", "text opt-out", "sms opt out", "sms opt-out", "no texts", "do not text"}
+
+// OptedOut reports whether the guest asked not to receive text messages.
+func (g *Guest) OptedOut() bool {
+	for title, value := range g.Extra {
+		title = strings.ToLower(strings.TrimSpace(title))
+		for _, c := range optOutColumns {
+			if title == c && isYes(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isYes(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "yes", "y", "true", "1", "x":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,27 @@
+package app
+
+import "testing"
+
+func TestOptedOut(t *testing.T) {
+	tests := []struct {
+		extra map[string]string
+		want  bool
+	}{
+		{nil, false},
+		{map[string]string{"Opt Out": "Yes"}, true},
+		{map[string]string{" do not text ": "x"}, true},
+		{map[string]string{"SMS opt-out": "1"}, true},
+		{map[string]string{"Opt Out": "TRUE"}, true},
+		{map[string]string{"Opt Out": ""}, false},
+		{map[string]string{"Opt Out": "no"}, false},
+		{map[string]string{"Opt Out": "ask first"}, false},
+		{map[string]string{"Opt Out": "N/A"}, false},
+		{map[string]string{"Notes": "yes"}, false},
+	}
+	for _, tt := range tests {
+		g := Guest{Extra: tt.extra}
+		if got := g.OptedOut(); got != tt.want {
+			t.Errorf("OptedOut(%v) = %v, want %v", tt.extra, got, tt.want)
+		}
+	}
+}
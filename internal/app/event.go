@@ -1,6 +1,8 @@
 package app
 
 import (
+	"fmt"
+
 	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
 	"github.com/andrew-tawfik/outreach-routing/internal/geoapi"
 )
@@ -9,6 +11,7 @@ import (
 type LocationRegistry struct {
 	DistanceMatrix [][]float64       
 	CoordianteMap  CoordinateMapping 
+	Depot          coordinates.GuestCoordinates
 }
 
 
@@ -33,9 +36,23 @@ type Event struct {
 
 
 type Guest struct {
+	ID          string
 	Name        string
 	GroupSize   int
+	PartyOf     int
 	Coordinates coordinates.GuestCoordinates
 	Address     string
 	PhoneNumber string
+	Unit        string
+	Notes       string
+	Extra       map[string]string
+	Row         int
+}
+
+// FullAddress is the address a driver needs, including the unit number.
+func (g *Guest) FullAddress() string {
+	if g.Unit == "" {
+		return g.Address
+	}
+	return fmt.Sprintf("Unit %s, %s", g.Unit, g.Address)
 }
@@ -0,0 +1,156 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+)
+
+// Household is the guests of one vehicle dropped off at the same location.
+// Guests at identical coordinates share a destination, so they ride
+// together unless the household is too large for one vehicle and the
+// coordinator splits it.
+type Household struct {
+	Coordinates coordinates.GuestCoordinates
+	Address     string
+	Guests      []Guest
+	People      int
+}
+
+// FitsOneVehicle reports whether the whole household can ride in one
+// vehicle.
+func (h Household) FitsOneVehicle() bool {
+	return h.People <= maxVehicleSeats
+}
+
+// Households groups the vehicle's guests by drop-off location, in stop
+// order.
+func (v *Vehicle) Households() []Household {
+	var households []Household
+	at := make(map[coordinates.GuestCoordinates]int)
+	for _, g := range v.Guests {
+		i, ok := at[g.Coordinates]
+		if !ok {
+			i = len(households)
+			at[g.Coordinates] = i
+			households = append(households, Household{Coordinates: g.Coordinates, Address: g.Address})
+		}
+		households[i].Guests = append(households[i].Guests, g)
+		households[i].People += g.GroupSize
+	}
+	return households
+}
+
+// HouseholdOf returns the household of the guest at index i of v.Guests.
+func (v *Vehicle) HouseholdOf(i int) Household {
+	for _, h := range v.Households() {
+		if h.Coordinates == v.Guests[i].Coordinates {
+			return h
+		}
+	}
+	return Household{}
+}
+
+// MoveGuest moves g from vehicle from to vehicle to, placing it at guest
+// position in the target as counted before the move. The rest of g's
+// household moves with it unless split is set. Guests whose household is
+// already partly on the target are placed next to it, and parts of a split
+// group that meet on one vehicle are joined into one guest. From may be
+// NoVehicle to place a guest from Unassigned. Each moved guest is recorded
+// as an edit. It returns how many guests moved, or an error without moving
+// anyone when the target has too few seats left for them.
+func (rm *RouteManager) MoveGuest(g *Guest, from, to, position int, split bool, lr *LocationRegistry, eventType string) (int, error) {
+	unassigned := Vehicle{Guests: rm.Unassigned}
+	src := &unassigned
+	if from != NoVehicle {
+		src = &rm.Vehicles[from]
+	}
+	gi := src.GuestIndex(g)
+	if gi < 0 {
+		return 0, nil
+	}
+
+	coord := src.Guests[gi].Coordinates
+	moving := make(map[int]bool)
+	people := 0
+	for i, other := range src.Guests {
+		if i == gi || (!split && other.Coordinates == coord) {
+			moving[i] = true
+			people += other.GroupSize
+		}
+	}
+
+	dst := &rm.Vehicles[to]
+	if from != to && dst.SeatsRemaining < people {
+		return 0, fmt.Errorf("%s has %d %s left, too few for %s's %d",
+			dst.DriverName(to), dst.SeatsRemaining, plural(dst.SeatsRemaining, "seat", "seats"), src.Guests[gi].Name, people)
+	}
+
+	moved := make([]Guest, 0, len(moving))
+	staying := make([]Guest, 0, len(src.Guests)-len(moving))
+	for i, other := range src.Guests {
+		if moving[i] {
+			moved = append(moved, other)
+			if from == to && i < position {
+				position--
+			}
+		} else {
+			staying = append(staying, other)
+		}
+	}
+	src.Guests = staying
+
+	if position > len(dst.Guests) {
+		position = len(dst.Guests)
+	}
+	for _, other := range dst.Guests {
+		if other.Coordinates == coord {
+			position = dst.guestPositionAfter(coord)
+			break
+		}
+	}
+
+	guests := make([]Guest, 0, len(dst.Guests)+len(moved))
+	guests = append(guests, dst.Guests[:position]...)
+	guests = append(guests, moved...)
+	guests = append(guests, dst.Guests[position:]...)
+	dst.Guests = guests
+
+	for i, m := range moved {
+		if from != to {
+			src.SeatsRemaining += m.GroupSize
+			dst.SeatsRemaining -= m.GroupSize
+		}
+		rm.RecordMove(m, from, to, position+i)
+	}
+
+	if from == NoVehicle {
+		rm.Unassigned = src.Guests
+	} else {
+		src.UpdateRouteFromGuests(lr, eventType)
+	}
+	if from != to {
+		dst.joinParts()
+		dst.UpdateRouteFromGuests(lr, eventType)
+	}
+	rm.refreshServedDestinations(lr)
+	return len(moved), nil
+}
+
+// refreshServedDestinations points each destination at the vehicle that
+// visits it. A destination split across vehicles points at the last one.
+func (rm *RouteManager) refreshServedDestinations(lr *LocationRegistry) {
+	if rm.ServedDestinations == nil {
+		rm.ServedDestinations = make(map[int]int)
+	}
+	for idx := range rm.ServedDestinations {
+		rm.ServedDestinations[idx] = -1
+	}
+	for i := range rm.Vehicles {
+		for _, coord := range rm.Vehicles[i].Locations {
+			if idx := lr.AddressIndex(coord); idx >= 0 {
+				rm.ServedDestinations[idx] = i
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package app
+
+import "testing"
+
+func TestMoveGuestChecksSeats(t *testing.T) {
+	lr, e := buildEvent("Dinner", []int{3, 1})
+	partner := e.Guests[1]
+	partner.ID, partner.Name, partner.GroupSize = "g3", "Guest 3", 2
+	lr.AddLocation(partner.Address, partner.Coordinates, partner.GroupSize)
+	e.Guests = append(e.Guests, partner)
+
+	rm := &RouteManager{Vehicles: []Vehicle{
+		{SeatsRemaining: 1, Guests: []Guest{e.Guests[0]}},
+		{SeatsRemaining: 1, Guests: []Guest{e.Guests[1], e.Guests[2]}},
+	}}
+	for i := range rm.Vehicles {
+		rm.Vehicles[i].UpdateRouteFromGuests(lr, e.EventType)
+	}
+
+	// Guest 2 shares an address with Guest 3, and the three of them do not
+	// fit the one seat left.
+	g := rm.Vehicles[1].Guests[0]
+	if n, err := rm.MoveGuest(&g, 1, 0, 1, false, lr, e.EventType); err == nil || n != 0 {
+		t.Fatalf("moved %d guests onto one seat, err = %v", n, err)
+	}
+	if len(rm.Vehicles[0].Guests) != 1 || len(rm.Vehicles[1].Guests) != 2 || len(rm.Edits) != 0 {
+		t.Fatalf("refused move changed the vehicles: %v", rm.Vehicles)
+	}
+
+	// Split off, Guest 2 alone takes the seat.
+	if n, err := rm.MoveGuest(&g, 1, 0, 1, true, lr, e.EventType); err != nil || n != 1 {
+		t.Fatalf("split move = %d, %v", n, err)
+	}
+	if rm.Vehicles[0].SeatsRemaining != 0 || rm.Vehicles[1].SeatsRemaining != 2 {
+		t.Errorf("seats remaining %d and %d, want 0 and 2", rm.Vehicles[0].SeatsRemaining, rm.Vehicles[1].SeatsRemaining)
+	}
+	mustValidate(t, "split move", rm, lr, e)
+
+	// Moving within a vehicle needs no seats.
+	g = rm.Vehicles[0].Guests[1]
+	if _, err := rm.MoveGuest(&g, 0, 0, 0, false, lr, e.EventType); err != nil {
+		t.Errorf("reordering a full vehicle: %v", err)
+	}
+}
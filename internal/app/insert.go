@@ -0,0 +1,233 @@
+package app
+
+import (
+	"math"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+)
+
+var DefaultDepot = coordinates.GuestCoordinates{Long: -75.726118, Lat: 45.396826}
+
+const maxRouteStops = 3
+
+// routeOffset converts between AddressOrder indices and the node indices
+// stored in Route.List. Grocery routes are built from CoordinateList, which
+// leaves out the depot.
+func routeOffset(eventType string) int {
+	if eventType == "Grocery" {
+		return 1
+	}
+	return 0
+}
+
+// AddLocation registers groupSize guests at coord. It returns the index of
+// the destination in AddressOrder, the coordinates it is registered at,
+// which are those already known for address if it was seen before, and
+// whether the destination is new, in which case the distance matrix must be
+// rebuilt before routing to it.
+func (lr *LocationRegistry) AddLocation(address string, coord coordinates.GuestCoordinates, groupSize int) (int, coordinates.GuestCoordinates, bool) {
+	cm := &lr.CoordianteMap
+	if cm.DestinationOccupancy == nil {
+		cm.DestinationOccupancy = make(map[coordinates.GuestCoordinates]int)
+	}
+	if cm.CoordinateToAddress == nil {
+		cm.CoordinateToAddress = make(map[string]coordinates.GuestCoordinates)
+	}
+
+	if existing, ok := cm.CoordinateToAddress[address]; ok {
+		coord = existing
+	}
+
+	if count, ok := cm.DestinationOccupancy[coord]; ok {
+		cm.DestinationOccupancy[coord] = count + groupSize
+		return lr.AddressIndex(coord), coord, false
+	}
+
+	cm.DestinationOccupancy[coord] = groupSize
+	cm.CoordinateToAddress[address] = coord
+	cm.AddressOrder = append(cm.AddressOrder, address)
+	addressOrder = cm.AddressOrder
+	return len(cm.AddressOrder) - 1, coord, true
+}
+
+// AddressIndex returns the AddressOrder index of the destination at coord, or
+// -1 if no guest destination is registered there.
+func (lr *LocationRegistry) AddressIndex(coord coordinates.GuestCoordinates) int {
+	for i := 1; i < len(lr.CoordianteMap.AddressOrder); i++ {
+		addr := lr.CoordianteMap.AddressOrder[i]
+		if lr.CoordianteMap.CoordinateToAddress[addr] == coord {
+			return i
+		}
+	}
+	return -1
+}
+
+// Coordinates lists the depot followed by every destination, in the order
+// used by the distance matrix.
+func (lr *LocationRegistry) Coordinates() []coordinates.GuestCoordinates {
+	ao := lr.CoordianteMap.AddressOrder
+	coords := make([]coordinates.GuestCoordinates, 0, len(ao))
+	coords = append(coords, lr.Depot)
+	for i := 1; i < len(ao); i++ {
+		coords = append(coords, lr.CoordianteMap.CoordinateToAddress[ao[i]])
+	}
+	return coords
+}
+
+// syncDestinations extends the per-destination bookkeeping after locations
+// were added to lr.
+func (rm *RouteManager) syncDestinations(lr *LocationRegistry) {
+	ao := lr.CoordianteMap.AddressOrder
+	for len(rm.DestinationGuestCount) < len(ao) {
+		rm.DestinationGuestCount = append(rm.DestinationGuestCount, 0)
+	}
+	if rm.ServedDestinations == nil {
+		rm.ServedDestinations = make(map[int]int)
+	}
+	for i, addr := range ao {
+		coord := lr.CoordianteMap.CoordinateToAddress[addr]
+		rm.DestinationGuestCount[i] = lr.CoordianteMap.DestinationOccupancy[coord]
+		if _, ok := rm.ServedDestinations[i]; !ok {
+			rm.ServedDestinations[i] = -1
+		}
+	}
+	rm.createCoordinateList(lr)
+}
+
+// InsertGuest adds a guest to an existing solution without re-running the
+// dispatch algorithm. The guest joins the vehicle already visiting its
+// destination when seats allow, otherwise the destination is placed at the
+// cheapest position of any vehicle with room, or on a new vehicle. The
+// guest's location must already be registered with lr.AddLocation, and
+// g.Coordinates must be the coordinates it returned; a guest whose
+// location is unknown is left in Unassigned and NoVehicle is returned. A
+// dinner group too large for one vehicle fills vehicles of its own first.
+// It returns the index of the vehicle the rest of the guest was assigned
+// to.
+func (rm *RouteManager) InsertGuest(g Guest, e *Event, lr *LocationRegistry) int {
+	rm.syncDestinations(lr)
+	e.Guests = append(e.Guests, g)
+
+	idx := lr.AddressIndex(g.Coordinates)
+	if idx < 0 {
+		rm.Unassigned = append(rm.Unassigned, g)
+		return NoVehicle
+	}
+	if e.EventType == "Dinner" {
+		g = rm.splitOversized([]Guest{g}, lr, e.EventType)[0]
+	}
+
+	vehicleIndex, guestPos := -1, 0
+	if served := rm.ServedDestinations[idx]; served >= 0 && served < len(rm.Vehicles) &&
+		rm.Vehicles[served].SeatsRemaining >= g.GroupSize {
+		vehicleIndex = served
+		guestPos = rm.Vehicles[served].guestPositionAfter(g.Coordinates)
+	} else {
+		vehicleIndex, guestPos = rm.cheapestInsertion(idx, g.GroupSize, e.EventType, lr)
+	}
+
+	if vehicleIndex == -1 {
+		rm.AddNewVehicle()
+		vehicleIndex = len(rm.Vehicles) - 1
+		guestPos = 0
+	}
+
+	v := &rm.Vehicles[vehicleIndex]
+	v.Guests = append(v.Guests[:guestPos], append([]Guest{g}, v.Guests[guestPos:]...)...)
+	v.SeatsRemaining -= g.GroupSize
+	v.UpdateRouteFromGuests(lr, e.EventType)
+	rm.ServedDestinations[idx] = vehicleIndex
+
+	return vehicleIndex
+}
+
+// cheapestInsertion finds the vehicle and stop position where visiting
+// destination idx adds the least distance. It returns the vehicle index and
+// the position in the vehicle's guest list, or -1 if no vehicle has room.
+func (rm *RouteManager) cheapestInsertion(idx, groupSize int, eventType string, lr *LocationRegistry) (int, int) {
+	bestVehicle, bestGuestPos := -1, 0
+	bestCost := math.Inf(1)
+	off := routeOffset(eventType)
+
+	for vi := range rm.Vehicles {
+		v := &rm.Vehicles[vi]
+		if v.Route.List == nil || v.Route.List.Len() == 0 {
+			continue
+		}
+		if v.SeatsRemaining < groupSize || v.Route.DestinationCount >= maxRouteStops {
+			continue
+		}
+
+		cost, guestPos := v.insertionCost(idx, off, lr)
+		if cost < bestCost {
+			bestCost = cost
+			bestVehicle = vi
+			bestGuestPos = guestPos
+		}
+	}
+
+	return bestVehicle, bestGuestPos
+}
+
+// insertionCost returns the least distance added by visiting destination idx
+// on v's route, and the guest list position of that stop. off is the
+// routeOffset of the event.
+func (v *Vehicle) insertionCost(idx, off int, lr *LocationRegistry) (float64, int) {
+	stops := make([]int, 0, v.Route.DestinationCount)
+	if v.Route.List != nil {
+		for elem := v.Route.List.Front(); elem != nil; elem = elem.Next() {
+			stops = append(stops, elem.Value.(int)+off)
+		}
+	}
+
+	bestCost, bestGuestPos := math.Inf(1), len(v.Guests)
+	for p := 0; p <= len(stops); p++ {
+		prev := 0
+		if p > 0 {
+			prev = stops[p-1]
+		}
+		cost := lr.distance(prev, idx)
+		if p < len(stops) {
+			cost += lr.distance(idx, stops[p]) - lr.distance(prev, stops[p])
+		}
+
+		if cost < bestCost {
+			bestCost = cost
+			bestGuestPos = v.guestsAtStops(stops[:p], lr)
+		}
+	}
+	return bestCost, bestGuestPos
+}
+
+func (lr *LocationRegistry) distance(from, to int) float64 {
+	if from < 0 || to < 0 || from >= len(lr.DistanceMatrix) || to >= len(lr.DistanceMatrix[from]) {
+		return math.Inf(1)
+	}
+	return lr.DistanceMatrix[from][to]
+}
+
+// guestsAtStops counts the guests of v dropped off at any of stops.
+func (v *Vehicle) guestsAtStops(stops []int, lr *LocationRegistry) int {
+	count := 0
+	for _, g := range v.Guests {
+		for _, s := range stops {
+			if lr.CoordianteMap.CoordinateToAddress[lr.CoordianteMap.AddressOrder[s]] == g.Coordinates {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// guestPositionAfter returns the guest list position right after the last
+// guest at coord.
+func (v *Vehicle) guestPositionAfter(coord coordinates.GuestCoordinates) int {
+	pos := len(v.Guests)
+	for i, g := range v.Guests {
+		if g.Coordinates == coord {
+			pos = i + 1
+		}
+	}
+	return pos
+}
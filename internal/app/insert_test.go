@@ -0,0 +1,107 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+)
+
+// buildEvent registers one destination per group size, spread around the
+// depot, with the matrix holding straight-line distances in metres.
+func buildEvent(eventType string, sizes []int) (*LocationRegistry, *Event) {
+	lr := &LocationRegistry{Depot: DefaultDepot}
+	lr.CoordianteMap.AddressOrder = []string{"depot"}
+	e := &Event{EventType: eventType}
+	for i, n := range sizes {
+		angle := float64(i) * 2.4
+		c := coordinates.GuestCoordinates{
+			Long: DefaultDepot.Long + 0.05*math.Cos(angle)*float64(1+i%4),
+			Lat:  DefaultDepot.Lat + 0.05*math.Sin(angle)*float64(1+i%4),
+		}
+		addr := fmt.Sprintf("%d Main St", i+1)
+		lr.AddLocation(addr, c, n)
+		e.Guests = append(e.Guests, Guest{
+			ID: fmt.Sprintf("g%d", i+1), Name: fmt.Sprintf("Guest %d", i+1),
+			GroupSize: n, Coordinates: c, Address: addr,
+		})
+	}
+	rebuildMatrix(lr)
+	return lr, e
+}
+
+func rebuildMatrix(lr *LocationRegistry) {
+	coords := lr.Coordinates()
+	lr.DistanceMatrix = make([][]float64, len(coords))
+	for i := range coords {
+		lr.DistanceMatrix[i] = make([]float64, len(coords))
+		for j := range coords {
+			lr.DistanceMatrix[i][j] = 100000 * math.Hypot(coords[i].Long-coords[j].Long, coords[i].Lat-coords[j].Lat)
+		}
+	}
+}
+
+func TestAddLocationKeepsKnownCoordinates(t *testing.T) {
+	lr, _ := buildEvent("Dinner", []int{2, 3})
+	moved := coordinates.GuestCoordinates{Long: -75.6, Lat: 45.5}
+
+	idx, coord, isNew := lr.AddLocation("1 Main St", moved, 1)
+	if isNew || idx != 1 {
+		t.Fatalf("AddLocation = %d, %v, want existing destination 1", idx, isNew)
+	}
+	if coord == moved || lr.AddressIndex(coord) != 1 {
+		t.Errorf("coord = %v, want the registered coordinates of 1 Main St", coord)
+	}
+}
+
+func TestInsertGuestAtKnownAddressWithNewCoordinates(t *testing.T) {
+	lr, e := buildEvent("Dinner", []int{2, 2, 1, 1})
+	rm, err := OrchestateDispatch(lr, e, 0)
+	if err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+
+	// A re-geocode of an address already routed.
+	g := Guest{ID: "new", Name: "New", GroupSize: 2, Address: "1 Main St",
+		Coordinates: coordinates.GuestCoordinates{Long: -75.6, Lat: 45.5}}
+	_, g.Coordinates, _ = lr.AddLocation(g.Address, g.Coordinates, g.GroupSize)
+
+	vi := rm.InsertGuest(g, e, lr)
+	if vi < 0 {
+		t.Fatalf("InsertGuest = %d, want a vehicle", vi)
+	}
+	if err := rm.Validate(lr, e); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInsertGuestAtUnknownLocation(t *testing.T) {
+	lr, e := buildEvent("Dinner", []int{2, 2})
+	rm, err := OrchestateDispatch(lr, e, 0)
+	if err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	vehicles := len(rm.Vehicles)
+
+	g := Guest{ID: "lost", Name: "Lost", GroupSize: 1, Address: "nowhere",
+		Coordinates: coordinates.GuestCoordinates{Long: -75.6, Lat: 45.5}}
+	if vi := rm.InsertGuest(g, e, lr); vi != NoVehicle {
+		t.Errorf("InsertGuest = %d, want NoVehicle", vi)
+	}
+	if len(rm.Unassigned) != 1 || len(rm.Vehicles) != vehicles {
+		t.Errorf("unassigned = %v, vehicles = %d, want the guest left unassigned", rm.Unassigned, len(rm.Vehicles))
+	}
+	if _, ok := rm.ServedDestinations[-1]; ok {
+		t.Error("ServedDestinations[-1] was written")
+	}
+}
+
+func TestDistanceRejectsOutOfRangeIndices(t *testing.T) {
+	lr, _ := buildEvent("Dinner", []int{1, 1})
+	for _, pair := range [][2]int{{0, -1}, {-1, 0}, {0, 3}, {3, 0}} {
+		if d := lr.distance(pair[0], pair[1]); !math.IsInf(d, 1) {
+			t.Errorf("distance(%d, %d) = %v, want +Inf", pair[0], pair[1], d)
+		}
+	}
+}
@@ -3,9 +3,11 @@ package app
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+	"github.com/andrew-tawfik/outreach-routing/internal/geoapi"
 )
 
 type AppData struct {
@@ -13,57 +15,91 @@ type AppData struct {
 	LocationRegistry LocationRegistry
 }
 
-func CoordinateKey(c coordinates.GuestCoordinates) string {
-	return fmt.Sprintf("%.6f,%.6f", c.Long, c.Lat)
-}
-
 
 type SerializableGuest struct {
+	ID          string `json:",omitempty"`
 	Name        string
 	GroupSize   int
-	Coordinates string 
+	PartyOf     int `json:",omitempty"`
+	Coordinates coordinates.GuestCoordinates
 	Address     string
 	PhoneNumber string
+	Unit        string            `json:",omitempty"`
+	Notes       string            `json:",omitempty"`
+	Extra       map[string]string `json:",omitempty"`
+	Row         int               `json:",omitempty"`
 }
 
 
 type SerializableEvent struct {
-	Guests    []SerializableGuest
-	EventType string
+	Guests       []SerializableGuest
+	EventType    string
+	FailedGuests []geoapi.FailedGuest `json:",omitempty"`
 }
 
-type SerializableCoordinateMapping struct {
-	DestinationOccupancy map[string]int
-	CoordinateToAddress  map[string]string
-	AddressOrder         []string
+// SerializableDestination is one row and column of the distance matrix.
+type SerializableDestination struct {
+	Address     string
+	Coordinates coordinates.GuestCoordinates
+	Guests      int `json:",omitempty"`
 }
 
+// SerializableLocationRegistry lists the depot and the destinations in
+// distance matrix order: the depot is index 0 and Destinations[i] is i+1.
 type SerializableLocationRegistry struct {
+	Depot          SerializableDestination
+	Destinations   []SerializableDestination
 	DistanceMatrix [][]float64
-	CoordinateMap  SerializableCoordinateMapping
 }
 
 type SerializableAppData struct {
+	Version          int
 	Event            SerializableEvent
 	LocationRegistry SerializableLocationRegistry
 }
 
+func toSerializableGuest(g Guest) SerializableGuest {
+	return SerializableGuest{
+		ID:          g.ID,
+		Name:        g.Name,
+		GroupSize:   g.GroupSize,
+		PartyOf:     g.PartyOf,
+		Coordinates: g.Coordinates,
+		Address:     g.Address,
+		PhoneNumber: g.PhoneNumber,
+		Unit:        g.Unit,
+		Notes:       g.Notes,
+		Extra:       g.Extra,
+		Row:         g.Row,
+	}
+}
+
+func fromSerializableGuest(sg SerializableGuest) Guest {
+	return Guest{
+		ID:          sg.ID,
+		Name:        sg.Name,
+		GroupSize:   sg.GroupSize,
+		PartyOf:     sg.PartyOf,
+		Coordinates: sg.Coordinates,
+		Address:     sg.Address,
+		PhoneNumber: sg.PhoneNumber,
+		Unit:        sg.Unit,
+		Notes:       sg.Notes,
+		Extra:       sg.Extra,
+		Row:         sg.Row,
+	}
+}
 
 func ConvertEventToSerializable(event Event) SerializableEvent {
 	guests := make([]SerializableGuest, len(event.Guests))
 	for i, g := range event.Guests {
-		guests[i] = SerializableGuest{
-			Name:        g.Name,
-			GroupSize:   g.GroupSize,
-			Coordinates: CoordinateKey(g.Coordinates),
-			Address:     g.Address,
-			PhoneNumber: g.PhoneNumber,
-		}
+		guests[i] = toSerializableGuest(g)
 	}
 
 	return SerializableEvent{
-		Guests:    guests,
-		EventType: event.EventType,
+		Guests:       guests,
+		EventType:    event.EventType,
+		FailedGuests: event.ApiErrors.FailedGuests,
 	}
 }
 
@@ -71,73 +107,98 @@ func ConvertEventToSerializable(event Event) SerializableEvent {
 func ConvertEventFromSerializable(se SerializableEvent) Event {
 	guests := make([]Guest, len(se.Guests))
 	for i, sg := range se.Guests {
-		var lat, long float64
-		fmt.Sscanf(sg.Coordinates, "%f,%f", &long, &lat)
-		guests[i] = Guest{
-			Name:        sg.Name,
-			GroupSize:   sg.GroupSize,
-			Coordinates: coordinates.GuestCoordinates{Long: long, Lat: lat},
-			Address:     sg.Address,
-			PhoneNumber: sg.PhoneNumber,
-		}
+		guests[i] = fromSerializableGuest(sg)
 	}
 
 	return Event{
 		Guests:    guests,
 		EventType: se.EventType,
+		ApiErrors: geoapi.ApiErrors{FailedGuests: se.FailedGuests},
 	}
 }
 
 func ConvertToSerializable(lr LocationRegistry) SerializableLocationRegistry {
-	occupancy := make(map[string]int)
-	for coord, count := range lr.CoordianteMap.DestinationOccupancy {
-		key := CoordinateKey(coord)
-		occupancy[key] = count
+	ao := lr.CoordianteMap.AddressOrder
+	slr := SerializableLocationRegistry{
+		Depot:          SerializableDestination{Coordinates: lr.Depot},
+		Destinations:   make([]SerializableDestination, 0, len(ao)),
+		DistanceMatrix: lr.DistanceMatrix,
 	}
-
-	address := make(map[string]string)
-	for addr, coord := range lr.CoordianteMap.CoordinateToAddress {
-		key := addr
-		address[key] = CoordinateKey(coord)
+	if len(ao) > 0 {
+		slr.Depot.Address = ao[0]
 	}
 
-	return SerializableLocationRegistry{
-		DistanceMatrix: lr.DistanceMatrix,
-		CoordinateMap: SerializableCoordinateMapping{
-			DestinationOccupancy: occupancy,
-			CoordinateToAddress:  address,
-			AddressOrder:         lr.CoordianteMap.AddressOrder,
-		},
+	for i := 1; i < len(ao); i++ {
+		coord := lr.CoordianteMap.CoordinateToAddress[ao[i]]
+		slr.Destinations = append(slr.Destinations, SerializableDestination{
+			Address:     ao[i],
+			Coordinates: coord,
+			Guests:      lr.CoordianteMap.DestinationOccupancy[coord],
+		})
 	}
+	return slr
 }
 
 func ConvertFromSerializable(slr SerializableLocationRegistry) LocationRegistry {
-	reverseOccupancy := make(map[coordinates.GuestCoordinates]int)
-	for key, count := range slr.CoordinateMap.DestinationOccupancy {
-		var lat, long float64
-		fmt.Sscanf(key, "%f,%f", &long, &lat)
-		reverseOccupancy[coordinates.GuestCoordinates{Long: long, Lat: lat}] = count
-	}
+	occupancy := make(map[coordinates.GuestCoordinates]int, len(slr.Destinations))
+	address := make(map[string]coordinates.GuestCoordinates, len(slr.Destinations))
+	order := make([]string, 0, len(slr.Destinations)+1)
 
-	reverseAddress := make(map[string]coordinates.GuestCoordinates)
-	for addr, coord := range slr.CoordinateMap.CoordinateToAddress {
-		var lat, long float64
-		fmt.Sscanf(coord, "%f,%f", &long, &lat)
-		reverseAddress[addr] = coordinates.GuestCoordinates{Long: long, Lat: lat}
+	order = append(order, slr.Depot.Address)
+	for _, d := range slr.Destinations {
+		occupancy[d.Coordinates] = d.Guests
+		address[d.Address] = d.Coordinates
+		order = append(order, d.Address)
 	}
 
 	return LocationRegistry{
 		DistanceMatrix: slr.DistanceMatrix,
 		CoordianteMap: CoordinateMapping{
-			DestinationOccupancy: reverseOccupancy,
-			CoordinateToAddress:  reverseAddress,
-			AddressOrder:         slr.CoordinateMap.AddressOrder,
+			DestinationOccupancy: occupancy,
+			CoordinateToAddress:  address,
+			AddressOrder:         order,
 		},
+		Depot: slr.Depot.Coordinates,
+	}
+}
+
+// validateAppData checks that a loaded event and registry fit together: the
+// distance matrix is square with a row per destination, destinations are
+// distinct, and every guest is at a registered destination.
+func validateAppData(e *Event, lr *LocationRegistry) error {
+	ao := lr.CoordianteMap.AddressOrder
+	matrix := lr.DistanceMatrix
+
+	if len(ao) == 0 {
+		return fmt.Errorf("no depot location")
+	}
+	if len(matrix) != len(ao) {
+		return fmt.Errorf("distance matrix has %d rows for %d locations", len(matrix), len(ao))
+	}
+	for i, row := range matrix {
+		if len(row) != len(matrix) {
+			return fmt.Errorf("distance matrix is not square: row %d has %d columns, expected %d", i, len(row), len(matrix))
+		}
+	}
+	if len(lr.CoordianteMap.CoordinateToAddress) != len(ao)-1 {
+		return fmt.Errorf("destination addresses are not unique")
 	}
+
+	for _, g := range e.Guests {
+		if _, ok := lr.CoordianteMap.CoordinateToAddress[g.Address]; ok {
+			continue
+		}
+		if lr.AddressIndex(g.Coordinates) >= 0 {
+			continue
+		}
+		return fmt.Errorf("guest %s: address %q is not a known destination", g.Name, g.Address)
+	}
+	return nil
 }
 
 func SaveAppDataToFile(filename string, event Event, lr LocationRegistry) error {
 	serializable := SerializableAppData{
+		Version:          SessionVersion,
 		Event:            ConvertEventToSerializable(event),
 		LocationRegistry: ConvertToSerializable(lr),
 	}
@@ -153,22 +214,51 @@ func SaveAppDataToFile(filename string, event Event, lr LocationRegistry) error
 	return encoder.Encode(serializable)
 }
 
+// LoadAppDataFromFile reads the event and locations of a saved session or
+// recorded event file of any version.
 func LoadAppDataFromFile(filename string) (Event, LocationRegistry, error) {
-	var serializable SerializableAppData
-
 	file, err := os.Open(filename)
 	if err != nil {
 		return Event{}, LocationRegistry{}, err
 	}
 	defer file.Close()
 
-	decoder := json.NewDecoder(file)
-	err = decoder.Decode(&serializable)
+	ss, err := decodeSession(file)
 	if err != nil {
 		return Event{}, LocationRegistry{}, err
 	}
 
-	event := ConvertEventFromSerializable(serializable.Event)
-	lr := ConvertFromSerializable(serializable.LocationRegistry)
+	event := ConvertEventFromSerializable(ss.Event)
+	lr := ConvertFromSerializable(ss.LocationRegistry)
+	if err := validateAppData(&event, &lr); err != nil {
+		return Event{}, LocationRegistry{}, fmt.Errorf("%s: %w", filename, err)
+	}
 	return event, lr, nil
 }
+
+// decodeSession reads a session or event file, migrating files written by
+// older versions to the current format.
+func decodeSession(r io.Reader) (SerializableSession, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return SerializableSession{}, err
+	}
+
+	var header struct{ Version int }
+	if err := json.Unmarshal(data, &header); err != nil {
+		return SerializableSession{}, fmt.Errorf("not a session file: %w", err)
+	}
+
+	switch {
+	case header.Version > SessionVersion:
+		return SerializableSession{}, fmt.Errorf("file version %d is newer than this app supports (%d)", header.Version, SessionVersion)
+	case header.Version < SessionVersion:
+		return migrateSession(data)
+	}
+
+	var ss SerializableSession
+	if err := json.Unmarshal(data, &ss); err != nil {
+		return SerializableSession{}, fmt.Errorf("could not read session file: %w", err)
+	}
+	return ss, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"time"
 
 	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
 	"github.com/mroth/weightedrand"
@@ -13,6 +14,10 @@ import (
 type Kmeans struct {
 	Clusters []Cluster
 	points   []Point
+
+	// Seed makes the centroid choice reproducible; zero picks a random seed.
+	Seed int64
+	rng  *rand.Rand
 }
 
 type Cluster struct {
@@ -33,6 +38,10 @@ func (km *Kmeans) GetName() string {
 }
 
 func (km *Kmeans) StartRouteDispatch(rm *RouteManager, lr *LocationRegistry) error {
+	if km.Seed == 0 {
+		km.Seed = time.Now().UnixNano()
+	}
+	km.rng = rand.New(rand.NewSource(km.Seed))
 
 	
 	km.init(rm)
@@ -43,7 +52,7 @@ func (km *Kmeans) StartRouteDispatch(rm *RouteManager, lr *LocationRegistry) err
 
 	
 	km.clusterData()
-	km.determineVehicleRoutes()
+	km.determineVehicleRoutes(rm)
 	
 
 	
@@ -63,9 +72,12 @@ func (km *Kmeans) init(rm *RouteManager) {
 	vehicleCount := ((totalDestinationCount + 2) / 3) + 2
 
 	for i := 0; i < vehicleCount; i++ {
-		newVehicle := Vehicle{Route: Route{List: list.New()}}
+		newVehicle := Vehicle{SeatsRemaining: maxVehicleSeats, Route: Route{List: list.New()}}
 		rm.Vehicles = append(rm.Vehicles, newVehicle)
+	}
 
+	// Clusters point into rm.Vehicles, so they are made once it stops growing.
+	for i := range rm.Vehicles {
 		newCluster := Cluster{vehicle: &rm.Vehicles[i], index: i}
 		km.Clusters = append(km.Clusters, newCluster)
 	}
@@ -75,7 +87,7 @@ func (km *Kmeans) determineCentroids(rm *RouteManager, lr *LocationRegistry) err
 	km.retreiveUniqueGuestCoordinates(rm, lr) 
 	centroids := make([]*coordinates.GuestCoordinates, 0)
 
-	randomIndex := rand.Intn(len(km.points))
+	randomIndex := km.rng.Intn(len(km.points))
 	km.Clusters[0].centroid = km.points[randomIndex].guestCoordinate
 	centroids = append(centroids, &km.Clusters[0].centroid) 
 
@@ -141,7 +153,7 @@ func (km *Kmeans) getRandomCoordinateIndex(probabilities []uint) (int, error) {
 		return -1, err
 	}
 
-	selected := chooser.Pick()
+	selected := chooser.PickSource(km.rng)
 	index, ok := selected.(int)
 	if !ok {
 		return -1, fmt.Errorf("failed to convert selected item to GuestCoordinates")
@@ -318,11 +330,16 @@ func (km *Kmeans) recalculateCentroids() {
 	}
 }
 
-func (km *Kmeans) determineVehicleRoutes() {
+// determineVehicleRoutes gives each cluster's vehicle its points as stops.
+// Points are numbered from the first destination, so the guests at point i
+// are counted at destination i+1.
+func (km *Kmeans) determineVehicleRoutes(rm *RouteManager) {
 	for i, point := range km.points {
 		if point.clusterIndex >= 0 && point.clusterIndex < len(km.Clusters) {
 			cluster := &km.Clusters[point.clusterIndex]
 			cluster.vehicle.Route.List.PushBack(i)
+			cluster.vehicle.Route.DestinationCount++
+			cluster.vehicle.SeatsRemaining -= rm.DestinationGuestCount[i+1]
 		}
 	}
 }
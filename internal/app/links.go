@@ -0,0 +1,67 @@
+package app
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+)
+
+// maxWaypoints is the most waypoints a Google Maps directions link may
+// carry; the mobile app ignores the rest.
+const maxWaypoints = 9
+
+func latLng(c coordinates.GuestCoordinates) string {
+	return fmt.Sprintf("%.6f,%.6f", c.Lat, c.Long)
+}
+
+// StopLocations returns the distinct guest locations in visiting order.
+func (v *Vehicle) StopLocations() []coordinates.GuestCoordinates {
+	stops := make([]coordinates.GuestCoordinates, 0, len(v.Guests))
+	seen := make(map[coordinates.GuestCoordinates]bool)
+	for _, g := range v.Guests {
+		if !seen[g.Coordinates] {
+			seen[g.Coordinates] = true
+			stops = append(stops, g.Coordinates)
+		}
+	}
+	return stops
+}
+
+// DirectionsURLs returns Google Maps driving directions from the depot
+// through the vehicle's stops in order. Routes with more stops than one link
+// allows are split into legs, each starting where the previous one ended.
+func (v *Vehicle) DirectionsURLs(depot coordinates.GuestCoordinates) []string {
+	stops := v.StopLocations()
+	links := make([]string, 0, 1)
+
+	origin := depot
+	for len(stops) > 0 {
+		n := min(len(stops), maxWaypoints+1)
+		leg := stops[:n]
+		stops = stops[n:]
+
+		params := url.Values{}
+		params.Set("api", "1")
+		params.Set("origin", latLng(origin))
+		params.Set("destination", latLng(leg[len(leg)-1]))
+		params.Set("travelmode", "driving")
+		if len(leg) > 1 {
+			waypoints := make([]string, len(leg)-1)
+			for i, c := range leg[:len(leg)-1] {
+				waypoints[i] = latLng(c)
+			}
+			params.Set("waypoints", strings.Join(waypoints, "|"))
+		}
+
+		links = append(links, "https://www.google.com/maps/dir/?"+params.Encode())
+		origin = leg[len(leg)-1]
+	}
+	return links
+}
+
+// WazeURL returns a link that starts Waze navigation to c.
+func WazeURL(c coordinates.GuestCoordinates) string {
+	return fmt.Sprintf("https://waze.com/ul?ll=%s&navigate=yes", latLng(c))
+}
@@ -0,0 +1,179 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+	"github.com/andrew-tawfik/outreach-routing/internal/geoapi"
+)
+
+// legacySession reads the version 0 and 1 file formats, which keyed
+// coordinates by "long,lat" strings. Version 0 files hold only the Event and
+// LocationRegistry.
+type legacySession struct {
+	Version   int
+	SavedAt   time.Time
+	Source    string
+	Worksheet string
+	Event     struct {
+		Guests    []legacyGuest
+		EventType string
+	}
+	ApiErrors        geoapi.ApiErrors
+	LocationRegistry struct {
+		DistanceMatrix [][]float64
+		CoordinateMap  struct {
+			DestinationOccupancy map[string]int
+			CoordinateToAddress  map[string]string
+			AddressOrder         []string
+		}
+		Depot string
+	}
+	Algorithm string
+	Seed      int64
+	Vehicles  []struct {
+		Driver         string
+		SeatsRemaining int
+		Route          []int
+		Guests         []legacyGuest
+	}
+	Edits []Edit
+}
+
+type legacyGuest struct {
+	Name        string
+	GroupSize   int
+	Coordinates string
+	Address     string
+	PhoneNumber string
+	Unit        string
+	Notes       string
+	Extra       map[string]string
+	Row         int
+}
+
+// parseCoordinateKey reads a "long,lat" coordinate string.
+func parseCoordinateKey(key string) (coordinates.GuestCoordinates, error) {
+	long, lat, ok := strings.Cut(key, ",")
+	if !ok {
+		return coordinates.GuestCoordinates{}, fmt.Errorf("invalid coordinates %q", key)
+	}
+	x, err := strconv.ParseFloat(strings.TrimSpace(long), 64)
+	if err != nil {
+		return coordinates.GuestCoordinates{}, fmt.Errorf("invalid longitude in %q", key)
+	}
+	y, err := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	if err != nil {
+		return coordinates.GuestCoordinates{}, fmt.Errorf("invalid latitude in %q", key)
+	}
+	return coordinates.GuestCoordinates{Long: x, Lat: y}, nil
+}
+
+func (lg legacyGuest) migrate() (SerializableGuest, error) {
+	coord, err := parseCoordinateKey(lg.Coordinates)
+	if err != nil {
+		return SerializableGuest{}, fmt.Errorf("guest %s: %w", lg.Name, err)
+	}
+	return SerializableGuest{
+		Name:        lg.Name,
+		GroupSize:   lg.GroupSize,
+		Coordinates: coord,
+		Address:     lg.Address,
+		PhoneNumber: lg.PhoneNumber,
+		Unit:        lg.Unit,
+		Notes:       lg.Notes,
+		Extra:       lg.Extra,
+		Row:         lg.Row,
+	}, nil
+}
+
+func migrateGuests(legacy []legacyGuest) ([]SerializableGuest, error) {
+	guests := make([]SerializableGuest, len(legacy))
+	for i, lg := range legacy {
+		g, err := lg.migrate()
+		if err != nil {
+			return nil, err
+		}
+		guests[i] = g
+	}
+	return guests, nil
+}
+
+// migrateSession converts a version 0 or 1 file to the current format.
+func migrateSession(data []byte) (SerializableSession, error) {
+	var old legacySession
+	if err := json.Unmarshal(data, &old); err != nil {
+		return SerializableSession{}, fmt.Errorf("could not read version %d session file: %w", old.Version, err)
+	}
+
+	guests, err := migrateGuests(old.Event.Guests)
+	if err != nil {
+		return SerializableSession{}, err
+	}
+
+	cm := old.LocationRegistry.CoordinateMap
+	slr := SerializableLocationRegistry{
+		Depot:          SerializableDestination{Coordinates: DefaultDepot},
+		Destinations:   make([]SerializableDestination, 0, len(cm.AddressOrder)),
+		DistanceMatrix: old.LocationRegistry.DistanceMatrix,
+	}
+	if old.LocationRegistry.Depot != "" {
+		if slr.Depot.Coordinates, err = parseCoordinateKey(old.LocationRegistry.Depot); err != nil {
+			return SerializableSession{}, fmt.Errorf("depot: %w", err)
+		}
+	}
+	if len(cm.AddressOrder) > 0 {
+		slr.Depot.Address = cm.AddressOrder[0]
+	}
+	for i := 1; i < len(cm.AddressOrder); i++ {
+		addr := cm.AddressOrder[i]
+		key, ok := cm.CoordinateToAddress[addr]
+		if !ok {
+			return SerializableSession{}, fmt.Errorf("destination %q has no coordinates", addr)
+		}
+		coord, err := parseCoordinateKey(key)
+		if err != nil {
+			return SerializableSession{}, fmt.Errorf("destination %q: %w", addr, err)
+		}
+		slr.Destinations = append(slr.Destinations, SerializableDestination{
+			Address:     addr,
+			Coordinates: coord,
+			Guests:      cm.DestinationOccupancy[key],
+		})
+	}
+
+	ss := SerializableSession{
+		SerializableAppData: SerializableAppData{
+			Version: SessionVersion,
+			Event: SerializableEvent{
+				Guests:       guests,
+				EventType:    old.Event.EventType,
+				FailedGuests: old.ApiErrors.FailedGuests,
+			},
+			LocationRegistry: slr,
+		},
+		SavedAt:   old.SavedAt,
+		Source:    old.Source,
+		Worksheet: old.Worksheet,
+		Algorithm: old.Algorithm,
+		Seed:      old.Seed,
+		Edits:     old.Edits,
+	}
+	for i, v := range old.Vehicles {
+		vehicleGuests, err := migrateGuests(v.Guests)
+		if err != nil {
+			return SerializableSession{}, fmt.Errorf("vehicle %d: %w", i+1, err)
+		}
+		ss.Vehicles = append(ss.Vehicles, SerializableVehicle{
+			Driver:         v.Driver,
+			SeatsRemaining: v.SeatsRemaining,
+			Route:          v.Route,
+			Guests:         vehicleGuests,
+		})
+	}
+	return ss, nil
+}
@@ -0,0 +1,180 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+)
+
+// A version 0 file is a recorded event: no version, no routes.
+const sessionV0 = `{
+  "Event": {
+    "EventType": "Dinner",
+    "Guests": [
+      {"Name": "Mina", "GroupSize": 2, "Coordinates": "-75.70,45.42", "Address": "1 Main St", "PhoneNumber": "613-555-0100"},
+      {"Name": "Sara", "GroupSize": 1, "Coordinates": "-75.68, 45.41", "Address": "3 Elm St", "Unit": "4"}
+    ]
+  },
+  "ApiErrors": {"FailedGuests": [{"Name": "Lost", "Address": "nowhere"}]},
+  "LocationRegistry": {
+    "DistanceMatrix": [[0, 3000, 4000], [3000, 0, 2000], [4000, 2000, 0]],
+    "CoordinateMap": {
+      "DestinationOccupancy": {"-75.70,45.42": 2, "-75.68, 45.41": 1},
+      "CoordinateToAddress": {"1 Main St": "-75.70,45.42", "3 Elm St": "-75.68, 45.41"},
+      "AddressOrder": ["depot", "1 Main St", "3 Elm St"]
+    },
+    "Depot": "-75.73,45.40"
+  }
+}`
+
+// A version 1 grocery session with its routes, whose seats were not kept.
+const sessionV1 = `{
+  "Version": 1,
+  "Source": "Guest sheet",
+  "Worksheet": "Grocery June 14",
+  "Algorithm": "Kmeans++",
+  "Seed": 42,
+  "Event": {
+    "EventType": "Grocery",
+    "Guests": [
+      {"Name": "Mina", "GroupSize": 0, "Coordinates": "-75.70,45.42", "Address": "1 Main St"},
+      {"Name": "Sara", "GroupSize": 0, "Coordinates": "-75.68,45.41", "Address": "3 Elm St"}
+    ]
+  },
+  "LocationRegistry": {
+    "DistanceMatrix": [[0, 3000, 4000], [3000, 0, 2000], [4000, 2000, 0]],
+    "CoordinateMap": {
+      "DestinationOccupancy": {"-75.70,45.42": 0, "-75.68,45.41": 0},
+      "CoordinateToAddress": {"1 Main St": "-75.70,45.42", "3 Elm St": "-75.68,45.41"},
+      "AddressOrder": ["depot", "1 Main St", "3 Elm St"]
+    }
+  },
+  "Vehicles": [
+    {"Driver": "Ahmed", "SeatsRemaining": 0, "Route": [1, 0], "Guests": [
+      {"Name": "Sara", "GroupSize": 0, "Coordinates": "-75.68,45.41", "Address": "3 Elm St"},
+      {"Name": "Mina", "GroupSize": 0, "Coordinates": "-75.70,45.42", "Address": "1 Main St"}
+    ]}
+  ],
+  "Edits": [{"Guest": "Sara", "Address": "3 Elm St", "From": 1, "To": 0, "Position": 0}]
+}`
+
+func TestLoadSessionMigrates(t *testing.T) {
+	mina := coordinates.GuestCoordinates{Long: -75.70, Lat: 45.42}
+	sara := coordinates.GuestCoordinates{Long: -75.68, Lat: 45.41}
+
+	tests := []struct {
+		name      string
+		data      string
+		eventType string
+		depot     coordinates.GuestCoordinates
+		seed      int64
+		vehicles  int
+	}{
+		{"version 0", sessionV0, "Dinner", coordinates.GuestCoordinates{Long: -75.73, Lat: 45.40}, 0, 1},
+		{"version 1", sessionV1, "Grocery", DefaultDepot, 42, 1},
+	}
+	for _, tt := range tests {
+		s, err := LoadSession(strings.NewReader(tt.data))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if s.DispatchErr != nil {
+			t.Errorf("%s: DispatchErr = %v", tt.name, s.DispatchErr)
+		}
+
+		e := s.Event
+		if e.EventType != tt.eventType || len(e.Guests) != 2 {
+			t.Fatalf("%s: event = %s with %d guests", tt.name, e.EventType, len(e.Guests))
+		}
+		if e.Guests[0].Coordinates != mina || e.Guests[1].Coordinates != sara {
+			t.Errorf("%s: guests at %v and %v, want %v and %v", tt.name, e.Guests[0].Coordinates, e.Guests[1].Coordinates, mina, sara)
+		}
+
+		lr := s.LocationRegistry
+		ao := lr.CoordianteMap.AddressOrder
+		if lr.Depot != tt.depot || len(ao) != 3 || ao[0] != "depot" || ao[1] != "1 Main St" || ao[2] != "3 Elm St" {
+			t.Errorf("%s: depot %v, order %q", tt.name, lr.Depot, ao)
+		}
+		if lr.CoordianteMap.CoordinateToAddress["3 Elm St"] != sara || lr.AddressIndex(sara) != 2 {
+			t.Errorf("%s: 3 Elm St is registered at %v", tt.name, lr.CoordianteMap.CoordinateToAddress["3 Elm St"])
+		}
+		if len(lr.DistanceMatrix) != 3 || lr.DistanceMatrix[1][2] != 2000 {
+			t.Errorf("%s: distance matrix = %v", tt.name, lr.DistanceMatrix)
+		}
+
+		if s.Routes.Seed != tt.seed || len(s.Routes.Vehicles) != tt.vehicles {
+			t.Errorf("%s: seed %d with %d vehicles, want %d with %d", tt.name, s.Routes.Seed, len(s.Routes.Vehicles), tt.seed, tt.vehicles)
+		}
+	}
+
+	s, err := LoadSession(strings.NewReader(sessionV0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g := s.Event.Guests[1]; g.Unit != "4" || len(s.Event.ApiErrors.FailedGuests) != 1 {
+		t.Errorf("version 0: guest %+v, failed guests %v", g, s.Event.ApiErrors.FailedGuests)
+	}
+	if d := s.LocationRegistry.CoordianteMap.DestinationOccupancy[mina]; d != 2 {
+		t.Errorf("version 0: 1 Main St has %d guests, want 2", d)
+	}
+
+	s, err = LoadSession(strings.NewReader(sessionV1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := s.Routes.Vehicles[0]
+	if v.Driver != "Ahmed" || v.SeatsRemaining != maxVehicleSeats || v.Route.DestinationCount != 2 || len(v.Guests) != 2 {
+		t.Errorf("version 1: vehicle = %+v", v)
+	}
+	if s.Routes.Algorithm != "Kmeans++" || len(s.Routes.Edits) != 1 || s.Worksheet != "Grocery June 14" {
+		t.Errorf("version 1: algorithm %q, edits %v, worksheet %q", s.Routes.Algorithm, s.Routes.Edits, s.Worksheet)
+	}
+}
+
+func TestParseCoordinateKey(t *testing.T) {
+	good := map[string]coordinates.GuestCoordinates{
+		"-75.7,45.42":     {Long: -75.7, Lat: 45.42},
+		" -75.7 , 45.42 ": {Long: -75.7, Lat: 45.42},
+	}
+	for key, want := range good {
+		if got, err := parseCoordinateKey(key); err != nil || got != want {
+			t.Errorf("parseCoordinateKey(%q) = %v, %v, want %v", key, got, err, want)
+		}
+	}
+	for _, key := range []string{"", "-75.7", "-75.7;45.42", "west,45.42", "-75.7,north"} {
+		if _, err := parseCoordinateKey(key); err == nil {
+			t.Errorf("parseCoordinateKey(%q) accepted a bad key", key)
+		}
+	}
+}
+
+func TestLoadSessionRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		problem string
+	}{
+		{"bad guest coordinates", strings.Replace(sessionV0, `"Coordinates": "-75.70,45.42"`, `"Coordinates": "-75.70"`, 1), "guest Mina"},
+		{"bad depot", strings.Replace(sessionV0, `"Depot": "-75.73,45.40"`, `"Depot": "depot"`, 1), "depot"},
+		{"bad destination key", strings.Replace(sessionV1, `"3 Elm St": "-75.68,45.41"`, `"3 Elm St": "x,45.41"`, 1), `"3 Elm St"`},
+		{"destination without coordinates", strings.Replace(sessionV1, `"AddressOrder": ["depot", "1 Main St", "3 Elm St"]`,
+			`"AddressOrder": ["depot", "1 Main St", "3 Elm St", "9 Oak St"]`, 1), `"9 Oak St" has no coordinates`},
+		{"short matrix", strings.Replace(sessionV0, `[[0, 3000, 4000], [3000, 0, 2000], [4000, 2000, 0]]`, `[[0, 3000, 4000], [3000, 0, 2000]]`, 1), "2 rows for 3 locations"},
+		{"ragged matrix", strings.Replace(sessionV0, `[4000, 2000, 0]]`, `[4000, 2000]]`, 1), "not square"},
+		{"route past the last destination", strings.Replace(sessionV1, `"Route": [1, 0]`, `"Route": [1, 2]`, 1), "unknown destination 2"},
+		{"route through the depot", strings.Replace(sessionV1, `"Route": [1, 0]`, `"Route": [1, -1]`, 1), "unknown destination -1"},
+		{"route before the depot", strings.Replace(sessionV1, `"Route": [1, 0]`, `"Route": [1, -2]`, 1), "unknown destination -2"},
+		{"guest at no destination", strings.Replace(sessionV0, `"Coordinates": "-75.68, 45.41", "Address": "3 Elm St", "Unit"`,
+			`"Coordinates": "-75.60,45.50", "Address": "9 Oak St", "Unit"`, 1), `"9 Oak St" is not a known destination`},
+		{"newer version", strings.Replace(sessionV1, `"Version": 1`, `"Version": 99`, 1), "newer"},
+		{"not JSON", "Dinner June 12", "not a session file"},
+	}
+	for _, tt := range tests {
+		_, err := LoadSession(strings.NewReader(tt.data))
+		if err == nil || !strings.Contains(err.Error(), tt.problem) {
+			t.Errorf("%s: err = %v, want it to mention %s", tt.name, err, tt.problem)
+		}
+	}
+}
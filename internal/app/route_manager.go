@@ -2,6 +2,7 @@ package app
 
 import (
 	"container/list"
+	"time"
 
 	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
 )
@@ -18,6 +19,7 @@ type Vehicle struct {
 	Route          Route
 	Guests         []Guest
 	Locations      []coordinates.GuestCoordinates
+	Driver         string
 }
 
 
@@ -26,6 +28,17 @@ type RouteManager struct {
 	ServedDestinations    map[int]int 
 	DestinationGuestCount []int       
 	CoordinateList        []coordinates.GuestCoordinates
+
+	Algorithm string
+	Seed      int64
+	Edits     []Edit
+	Previous  Baseline
+
+	Unassigned []Guest
+
+	// splitVehicles lists, by destination, the vehicles reserved for a
+	// group too large for one vehicle while routes are built.
+	splitVehicles map[int][]int
 }
 
 
@@ -41,7 +54,8 @@ type VRPAlgorithm interface {
 
 
 
-func OrchestateDispatch(lr *LocationRegistry, e *Event) *RouteManager {
+// newRouteManager sets up an empty RouteManager for the destinations of lr.
+func newRouteManager(lr *LocationRegistry) *RouteManager {
 
 	ao := &lr.CoordianteMap.AddressOrder
 	destinationCount := &lr.CoordianteMap.DestinationOccupancy
@@ -68,23 +82,44 @@ func OrchestateDispatch(lr *LocationRegistry, e *Event) *RouteManager {
 		DestinationGuestCount: destinationGuestCount,
 	}
 	rm.createCoordinateList(lr)
+	return rm
+}
+
+// OrchestateDispatch routes the event with the algorithm for its type.
+// Grocery routes are seeded with seed, or a random seed when it is zero, so
+// a recorded run can be routed again the same way. The returned routes are
+// always usable; a *DispatchError reports guests the algorithm could not
+// place, which are left in Unassigned, and a *ValidationError routes that
+// fail Validate.
+func OrchestateDispatch(lr *LocationRegistry, e *Event, seed int64) (*RouteManager, error) {
+	rm := newRouteManager(lr)
 
 	var strategy VRPAlgorithm
 	if e.EventType == "Dinner" {
 		strategy = &ClarkeWright{}
 	} else {
-		strategy = &Kmeans{}
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		rm.Seed = seed
+		strategy = &Kmeans{Seed: seed}
 	}
-	strategy.StartRouteDispatch(rm, lr)
+	rm.Algorithm = strategy.GetName()
+	err := strategy.StartRouteDispatch(rm, lr)
 
 	rm.determineGuestsInvolved(e, lr)
-	return rm
+	return rm, rm.checkDispatch(e, lr, err)
 }
 
 func (rm *RouteManager) determineGuestsInvolved(e *Event, lr *LocationRegistry) {
+	rm.dropUnusedVehicles()
+	rest := rm.shareSplitDestinations(e, lr)
 
 	for i := range rm.Vehicles {
 		v := &rm.Vehicles[i]
+		if rm.isSplitVehicle(i) {
+			continue
+		}
 
 		var nodeVisited []int
 		for elem := v.Route.List.Front(); elem != nil; elem = elem.Next() {
@@ -93,9 +128,23 @@ func (rm *RouteManager) determineGuestsInvolved(e *Event, lr *LocationRegistry)
 
 		addresses := determineAddressesVisited(nodeVisited, e)
 		v.determineCoordinates(addresses, lr)
-		v.findGuests(addresses, e, lr)
+		v.findGuests(addresses, e, lr, rest)
 	}
+	rm.splitVehicles = nil
+	rm.refreshServedDestinations(lr)
+}
 
+// dropUnusedVehicles removes vehicles added for a route that could not be
+// started. Vehicles reserved for split groups come first, so their indices
+// are unchanged.
+func (rm *RouteManager) dropUnusedVehicles() {
+	used := rm.Vehicles[:0]
+	for _, v := range rm.Vehicles {
+		if v.Route.List != nil {
+			used = append(used, v)
+		}
+	}
+	rm.Vehicles = used
 }
 
 func (rm *RouteManager) createCoordinateList(lr *LocationRegistry) {
@@ -119,3 +168,23 @@ func (v *Vehicle) determineCoordinates(addresses []string, lr *LocationRegistry)
 	}
 	v.Locations = vehicleCoordinates
 }
+
+// GuestIndex returns the position of g in v.Guests, or -1. Guests are
+// matched by ID, or by name and address when they have none, as in
+// sessions saved by earlier versions.
+func (v *Vehicle) GuestIndex(g *Guest) int {
+	for i := range v.Guests {
+		if &v.Guests[i] == g {
+			return i
+		}
+	}
+	for i, other := range v.Guests {
+		if g.ID != "" && other.ID == g.ID {
+			return i
+		}
+		if g.ID == "" && other.Name == g.Name && other.Address == g.Address {
+			return i
+		}
+	}
+	return -1
+}
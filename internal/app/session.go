@@ -0,0 +1,202 @@
+package app
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+)
+
+// SessionVersion is the session file format written by SaveSession.
+// Version 1 stored coordinates as "long,lat" strings and version 0 files
+// are recorded events without routes; both are migrated when loaded.
+const SessionVersion = 2
+
+// Edit is one manual move of a guest on the route planning grid. From and
+// To are vehicle indices and Position is the guest's new place in the
+// target vehicle.
+type Edit struct {
+	Time     time.Time
+	Guest    string
+	GuestID  string `json:",omitempty"`
+	Address  string
+	From     int
+	To       int
+	Position int
+}
+
+// RecordMove adds a manual move of g to the edit history.
+func (rm *RouteManager) RecordMove(g Guest, from, to, position int) {
+	rm.Edits = append(rm.Edits, Edit{
+		Time:     time.Now(),
+		Guest:    g.Name,
+		GuestID:  g.ID,
+		Address:  g.Address,
+		From:     from,
+		To:       to,
+		Position: position,
+	})
+}
+
+// Session is everything needed to continue working on an event later: the
+// guests and distances, the routes as edited, and where the guests came
+// from.
+type Session struct {
+	Event            Event
+	LocationRegistry LocationRegistry
+	Routes           *RouteManager
+	Source           string
+	Worksheet        string
+	SavedAt          time.Time
+
+	// DispatchErr is the error of routing an event file saved without
+	// routes, as returned by OrchestateDispatch, or the *ValidationError of
+	// restored routes that fail Validate. The routes are still set.
+	DispatchErr error
+}
+
+type SerializableVehicle struct {
+	Driver         string `json:",omitempty"`
+	SeatsRemaining int
+	Route          []int
+	Guests         []SerializableGuest
+}
+
+// SerializableSession extends the event data with the routes, so an event
+// file without vehicles is also a valid session.
+type SerializableSession struct {
+	SerializableAppData
+	SavedAt   time.Time
+	Source    string                `json:",omitempty"`
+	Worksheet string                `json:",omitempty"`
+	Algorithm string                `json:",omitempty"`
+	Seed      int64                 `json:",omitempty"`
+	Vehicles  []SerializableVehicle `json:",omitempty"`
+	Edits     []Edit                `json:",omitempty"`
+	Previous  Baseline              `json:",omitempty"`
+
+	Unassigned []SerializableGuest `json:",omitempty"`
+}
+
+// SaveSession writes s as indented JSON.
+func SaveSession(w io.Writer, s Session) error {
+	ss := SerializableSession{
+		SerializableAppData: SerializableAppData{
+			Version:          SessionVersion,
+			Event:            ConvertEventToSerializable(s.Event),
+			LocationRegistry: ConvertToSerializable(s.LocationRegistry),
+		},
+		SavedAt:   s.SavedAt,
+		Source:    s.Source,
+		Worksheet: s.Worksheet,
+		Algorithm: s.Routes.Algorithm,
+		Seed:      s.Routes.Seed,
+		Vehicles:  make([]SerializableVehicle, len(s.Routes.Vehicles)),
+		Edits:     s.Routes.Edits,
+		Previous:  s.Routes.Previous,
+	}
+	for _, g := range s.Routes.Unassigned {
+		ss.Unassigned = append(ss.Unassigned, toSerializableGuest(g))
+	}
+
+	for i, v := range s.Routes.Vehicles {
+		sv := SerializableVehicle{
+			Driver:         v.Driver,
+			SeatsRemaining: v.SeatsRemaining,
+			Route:          make([]int, 0, v.Route.DestinationCount),
+			Guests:         make([]SerializableGuest, len(v.Guests)),
+		}
+		if v.Route.List != nil {
+			for elem := v.Route.List.Front(); elem != nil; elem = elem.Next() {
+				sv.Route = append(sv.Route, elem.Value.(int))
+			}
+		}
+		for j, g := range v.Guests {
+			sv.Guests[j] = toSerializableGuest(g)
+		}
+		ss.Vehicles[i] = sv
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(ss)
+}
+
+// LoadSession reads a session file of any version, checks it and rebuilds
+// its routes. Event files without routes are dispatched afresh with their
+// saved seed and saved routes are validated, with any problem reported in
+// Session.DispatchErr.
+func LoadSession(r io.Reader) (Session, error) {
+	ss, err := decodeSession(r)
+	if err != nil {
+		return Session{}, err
+	}
+
+	event := ConvertEventFromSerializable(ss.Event)
+	lr := ConvertFromSerializable(ss.LocationRegistry)
+	if err := validateAppData(&event, &lr); err != nil {
+		return Session{}, fmt.Errorf("invalid session file: %w", err)
+	}
+
+	session := Session{
+		Event:            event,
+		LocationRegistry: lr,
+		Source:           ss.Source,
+		Worksheet:        ss.Worksheet,
+		SavedAt:          ss.SavedAt,
+	}
+	if len(ss.Vehicles) == 0 {
+		// Guests the dispatch leaves behind are kept in Routes.Unassigned
+		// for the coordinator to place.
+		session.Routes, session.DispatchErr = OrchestateDispatch(&session.LocationRegistry, &session.Event, ss.Seed)
+		return session, nil
+	}
+
+	rm := newRouteManager(&session.LocationRegistry)
+	rm.Algorithm = ss.Algorithm
+	rm.Seed = ss.Seed
+	rm.Edits = ss.Edits
+	rm.Previous = ss.Previous
+	for _, sg := range ss.Unassigned {
+		rm.Unassigned = append(rm.Unassigned, fromSerializableGuest(sg))
+	}
+
+	off := routeOffset(event.EventType)
+	for i, sv := range ss.Vehicles {
+		v := Vehicle{
+			SeatsRemaining: sv.SeatsRemaining,
+			Driver:         sv.Driver,
+			Guests:         make([]Guest, len(sv.Guests)),
+			Route:          Route{List: list.New(), DestinationCount: len(sv.Route)},
+			Locations:      make([]coordinates.GuestCoordinates, 0, len(sv.Route)),
+		}
+		for j, sg := range sv.Guests {
+			v.Guests[j] = fromSerializableGuest(sg)
+		}
+		if event.EventType == "Grocery" {
+			// Grocery seats were not kept before they were validated; the
+			// guests count as no people, so every vehicle has all its seats.
+			v.SeatsRemaining = maxVehicleSeats
+			for _, g := range v.Guests {
+				v.SeatsRemaining -= g.GroupSize
+			}
+		}
+		for _, node := range sv.Route {
+			idx := node + off
+			if idx <= 0 || idx >= len(lr.CoordianteMap.AddressOrder) {
+				return Session{}, fmt.Errorf("vehicle %d visits unknown destination %d", i+1, node)
+			}
+			v.Route.List.PushBack(node)
+			v.Locations = append(v.Locations, lr.CoordianteMap.CoordinateToAddress[lr.CoordianteMap.AddressOrder[idx]])
+			rm.ServedDestinations[idx] = i
+		}
+		rm.Vehicles = append(rm.Vehicles, v)
+	}
+
+	session.Routes = rm
+	session.DispatchErr = rm.Validate(&session.LocationRegistry, &session.Event)
+	return session, nil
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// roundTrip saves s and loads it back.
+func roundTrip(t *testing.T, s Session) Session {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := SaveSession(&buf, s); err != nil {
+		t.Fatalf("SaveSession: %v", err)
+	}
+	loaded, err := LoadSession(&buf)
+	if err != nil {
+		t.Fatalf("LoadSession: %v", err)
+	}
+	return loaded
+}
+
+func TestLoadSessionReportsDispatchError(t *testing.T) {
+	// No two of these groups fit one vehicle, which the savings routes
+	// cannot place.
+	lr, e := buildEvent("Dinner", []int{4, 4, 3})
+	s := roundTrip(t, Session{Event: *e, LocationRegistry: *lr, Routes: &RouteManager{}})
+
+	var dispatchErr *DispatchError
+	if !errors.As(s.DispatchErr, &dispatchErr) {
+		t.Fatalf("DispatchErr = %v, want a *DispatchError", s.DispatchErr)
+	}
+	if len(dispatchErr.Unassigned) == 0 || len(s.Routes.Unassigned) != len(dispatchErr.Unassigned) {
+		t.Errorf("unassigned = %v, routes keep %v", dispatchErr.Unassigned, s.Routes.Unassigned)
+	}
+}
+
+func TestLoadSessionWithRoutesHasNoDispatchError(t *testing.T) {
+	lr, e := buildEvent("Dinner", []int{2, 2, 1, 1})
+	rm, err := OrchestateDispatch(lr, e, 0)
+	if err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	s := roundTrip(t, Session{Event: *e, LocationRegistry: *lr, Routes: rm})
+	if s.DispatchErr != nil {
+		t.Errorf("DispatchErr = %v, want nil", s.DispatchErr)
+	}
+	if len(s.Routes.Vehicles) != len(rm.Vehicles) {
+		t.Errorf("loaded %d vehicles, saved %d", len(s.Routes.Vehicles), len(rm.Vehicles))
+	}
+}
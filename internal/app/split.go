@@ -0,0 +1,148 @@
+package app
+
+import (
+	"container/list"
+	"fmt"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+)
+
+// SizeLabel is the guest's group size as shown on the route grid, "2 of 6"
+// for part of a group split between vehicles.
+func (g *Guest) SizeLabel() string {
+	if g.PartyOf > 0 {
+		return fmt.Sprintf("%d of %d", g.GroupSize, g.PartyOf)
+	}
+	return fmt.Sprintf("%d", g.GroupSize)
+}
+
+// takePeople loads up to n people from the front of guests, splitting the
+// last group when it does not fit whole. It returns the load and the guests
+// left behind.
+func takePeople(guests []Guest, n int) ([]Guest, []Guest) {
+	var load []Guest
+	for len(guests) > 0 && n > 0 {
+		g := guests[0]
+		if g.GroupSize <= n {
+			load = append(load, g)
+			n -= g.GroupSize
+			guests = guests[1:]
+			continue
+		}
+
+		if g.PartyOf == 0 {
+			g.PartyOf = g.GroupSize
+		}
+		part, rest := g, g
+		part.GroupSize = n
+		rest.GroupSize -= n
+		load = append(load, part)
+		guests = append([]Guest{rest}, guests[1:]...)
+		n = 0
+	}
+	return load, guests
+}
+
+// splitOversized fills vehicles of their own with the guests of one
+// destination until the rest fit in one vehicle, and returns the rest.
+func (rm *RouteManager) splitOversized(guests []Guest, lr *LocationRegistry, eventType string) []Guest {
+	people := 0
+	for _, g := range guests {
+		people += g.GroupSize
+	}
+
+	for people > maxVehicleSeats {
+		var load []Guest
+		load, guests = takePeople(guests, maxVehicleSeats)
+		rm.AddNewVehicle()
+		rm.Vehicles[len(rm.Vehicles)-1].addGuestsAt(0, load, lr, eventType)
+		people -= maxVehicleSeats
+	}
+	return guests
+}
+
+// reserveFullVehicles sends full vehicles to destinations with more guests
+// than one vehicle seats, leaving at most one vehicle's worth for the
+// savings routes, which can then pick up the rest on a shared route. The
+// guests are shared out by determineGuestsInvolved.
+func (rm *RouteManager) reserveFullVehicles() {
+	rm.splitVehicles = make(map[int][]int)
+	for idx := 1; idx < len(rm.DestinationGuestCount); idx++ {
+		for rm.DestinationGuestCount[idx] > maxVehicleSeats {
+			rm.AddNewVehicle()
+			vi := len(rm.Vehicles) - 1
+			v := &rm.Vehicles[vi]
+			v.Route.List = list.New()
+			v.Route.List.PushBack(idx)
+			v.Route.DestinationCount = 1
+			v.SeatsRemaining = 0
+
+			rm.DestinationGuestCount[idx] -= maxVehicleSeats
+			rm.splitVehicles[idx] = append(rm.splitVehicles[idx], vi)
+		}
+	}
+}
+
+// shareSplitDestinations loads the vehicles reserved for oversized
+// destinations and returns, by location, the guests left for the vehicle
+// whose route also passes there.
+func (rm *RouteManager) shareSplitDestinations(e *Event, lr *LocationRegistry) map[coordinates.GuestCoordinates][]Guest {
+	rest := make(map[coordinates.GuestCoordinates][]Guest)
+	for idx, vehicles := range rm.splitVehicles {
+		coord := lr.CoordianteMap.CoordinateToAddress[lr.CoordianteMap.AddressOrder[idx]]
+		var guests []Guest
+		for _, g := range e.Guests {
+			if g.Coordinates == coord {
+				guests = append(guests, g)
+			}
+		}
+
+		for _, vi := range vehicles {
+			v := &rm.Vehicles[vi]
+			v.Guests, guests = takePeople(guests, maxVehicleSeats)
+			v.Locations = []coordinates.GuestCoordinates{coord}
+		}
+		rest[coord] = guests
+		rm.DestinationGuestCount[idx] += len(vehicles) * maxVehicleSeats
+	}
+	return rest
+}
+
+// isSplitVehicle reports whether vehicle i was reserved for an oversized
+// destination.
+func (rm *RouteManager) isSplitVehicle(i int) bool {
+	for _, vehicles := range rm.splitVehicles {
+		for _, vi := range vehicles {
+			if vi == i {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// joinParts merges parts of a split group that ride in the same vehicle
+// back into one guest. Parts are matched by ID, or by name and address for
+// guests without one.
+func (v *Vehicle) joinParts() {
+	joined := v.Guests[:0]
+	at := make(map[string]int)
+	for _, g := range v.Guests {
+		if g.PartyOf == 0 {
+			joined = append(joined, g)
+			continue
+		}
+		key := assignmentKey(g)
+		i, ok := at[key]
+		if !ok {
+			at[key] = len(joined)
+			joined = append(joined, g)
+			continue
+		}
+		joined[i].GroupSize += g.GroupSize
+		if joined[i].GroupSize >= joined[i].PartyOf {
+			joined[i].PartyOf = 0
+		}
+	}
+	v.Guests = joined
+}
@@ -0,0 +1,126 @@
+package app
+
+import "testing"
+
+// routedSplit dispatches a dinner with one group of six beside smaller
+// groups, which splits the six between two vehicles.
+func routedSplit(t *testing.T, id string) (*LocationRegistry, *Event, *RouteManager) {
+	t.Helper()
+	lr, e := buildEvent("Dinner", []int{6, 1, 1, 2})
+	e.Guests[0].ID = id
+	rm, err := OrchestateDispatch(lr, e, 0)
+	if err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	return lr, e, rm
+}
+
+// parts returns the vehicles carrying a part of the guest named name, and
+// the size of each part.
+func parts(rm *RouteManager, name string) (vehicles []int, sizes []int) {
+	for i := range rm.Vehicles {
+		for _, g := range rm.Vehicles[i].Guests {
+			if g.Name == name {
+				vehicles = append(vehicles, i)
+				sizes = append(sizes, g.GroupSize)
+			}
+		}
+	}
+	return vehicles, sizes
+}
+
+func TestSplitGroupDispatch(t *testing.T) {
+	for _, id := range []string{"g1", ""} {
+		_, _, rm := routedSplit(t, id)
+		vehicles, sizes := parts(rm, "Guest 1")
+		if len(vehicles) != 2 || sizes[0]+sizes[1] != 6 {
+			t.Errorf("id %q: Guest 1 rides in %v with %v people, want two parts of 6", id, vehicles, sizes)
+		}
+		for _, vi := range vehicles {
+			g := rm.Vehicles[vi].Guests[nameIndex(&rm.Vehicles[vi], "Guest 1")]
+			if g.PartyOf != 6 {
+				t.Errorf("id %q: part of %d has PartyOf %d, want 6", id, g.GroupSize, g.PartyOf)
+			}
+		}
+	}
+}
+
+func TestMovingPartOntoOtherPartIsRefused(t *testing.T) {
+	for _, id := range []string{"g1", ""} {
+		lr, e, rm := routedSplit(t, id)
+		vehicles, sizes := parts(rm, "Guest 1")
+		from, to := vehicles[1], vehicles[0]
+		seats := rm.Vehicles[to].SeatsRemaining
+
+		// The parts of a split group never fit one vehicle together.
+		src := &rm.Vehicles[from]
+		n, err := rm.MoveGuest(&src.Guests[nameIndex(src, "Guest 1")], from, to, 0, true, lr, e.EventType)
+		if err == nil || n != 0 {
+			t.Fatalf("id %q: moved %d guests onto %d seats, err = %v", id, n, seats, err)
+		}
+		if v, s := parts(rm, "Guest 1"); len(v) != 2 || s[0] != sizes[0] || s[1] != sizes[1] || len(rm.Edits) != 0 {
+			t.Errorf("id %q: refused move left Guest 1 in %v with %v people and %d edits", id, v, s, len(rm.Edits))
+		}
+		if err := rm.Validate(lr, e); err != nil {
+			t.Errorf("id %q: %v", id, err)
+		}
+	}
+}
+
+func TestJoinParts(t *testing.T) {
+	for _, id := range []string{"g1", ""} {
+		v := Vehicle{Guests: []Guest{
+			{ID: id, Name: "Guest 1", Address: "1 Main St", GroupSize: 4, PartyOf: 9},
+			{ID: "g2", Name: "Guest 2", Address: "2 Main St", GroupSize: 1},
+			{ID: id, Name: "Guest 1", Address: "1 Main St", GroupSize: 1, PartyOf: 9},
+		}}
+		v.joinParts()
+		if len(v.Guests) != 2 || v.Guests[0].SizeLabel() != "5 of 9" || v.Guests[1].Name != "Guest 2" {
+			t.Errorf("id %q: joined a part of 9 into %v", id, v.Guests)
+		}
+
+		v.Guests = append(v.Guests, Guest{ID: id, Name: "Guest 1", Address: "1 Main St", GroupSize: 4, PartyOf: 9})
+		v.joinParts()
+		if len(v.Guests) != 2 || v.Guests[0].PartyOf != 0 || v.Guests[0].SizeLabel() != "9" {
+			t.Errorf("id %q: joined the whole group into %v", id, v.Guests)
+		}
+	}
+}
+
+func TestAssignmentsOfSplitGroup(t *testing.T) {
+	_, _, rm := routedSplit(t, "g1")
+	vehicles, sizes := parts(rm, "Guest 1")
+	larger := vehicles[0]
+	if sizes[1] > sizes[0] {
+		larger = vehicles[1]
+	}
+
+	b := rm.Assignments()
+	want := rm.Vehicles[larger].DriverName(larger)
+	for _, key := range BaselineKeys("g1", "Guest 1") {
+		if a := b[key]; a.Driver != want || a.Vehicle != larger {
+			t.Errorf("%s = %+v, want %s in vehicle %d", key, a, want, larger)
+		}
+	}
+}
+
+func TestGuestChangeOfSplitGroup(t *testing.T) {
+	_, _, rm := routedSplit(t, "g1")
+	rm.Previous = rm.Assignments()
+	vehicles, _ := parts(rm, "Guest 1")
+	for _, vi := range vehicles {
+		g := rm.Vehicles[vi].Guests[nameIndex(&rm.Vehicles[vi], "Guest 1")]
+		if change, label := rm.GuestChange(g, vi); change != Unchanged || label != "" {
+			t.Errorf("part in vehicle %d reads %q, want no change", vi, label)
+		}
+	}
+}
+
+func nameIndex(v *Vehicle, name string) int {
+	for i, g := range v.Guests {
+		if g.Name == name {
+			return i
+		}
+	}
+	return -1
+}
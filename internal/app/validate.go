@@ -0,0 +1,158 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ValidationError lists the ways a RouteManager disagrees with its event
+// and locations.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "routes are inconsistent: " + strings.Join(e.Problems, "; ")
+}
+
+// Validate checks the routes against the event and locations: seats
+// remaining match the guests on board, each route's stop count and
+// locations match its list, every guest's stop is on their vehicle's route,
+// every guest of e is carried once or is in Unassigned, and
+// ServedDestinations points each visited destination at a vehicle that
+// visits it. Grocery guests count as no people, so grocery vehicles keep
+// all their seats. It returns a *ValidationError or nil.
+func (rm *RouteManager) Validate(lr *LocationRegistry, e *Event) error {
+	var problems []string
+	fail := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	ao := lr.CoordianteMap.AddressOrder
+	off := routeOffset(e.EventType)
+	visitedBy := make(map[int][]int)
+
+	for i := range rm.Vehicles {
+		v := &rm.Vehicles[i]
+		name := v.DriverName(i)
+
+		people := 0
+		for _, g := range v.Guests {
+			people += g.GroupSize
+		}
+		if v.SeatsRemaining != maxVehicleSeats-people {
+			fail("%s has %d seats remaining but carries %d people", name, v.SeatsRemaining, people)
+		}
+
+		var nodes []int
+		if v.Route.List != nil {
+			for elem := v.Route.List.Front(); elem != nil; elem = elem.Next() {
+				nodes = append(nodes, elem.Value.(int))
+			}
+		}
+		if v.Route.DestinationCount != len(nodes) {
+			fail("%s counts %d stops but its route has %d", name, v.Route.DestinationCount, len(nodes))
+		}
+		if len(v.Locations) != len(nodes) {
+			fail("%s has %d locations for %d stops", name, len(v.Locations), len(nodes))
+		}
+
+		var stopOrder []string
+		stops := make(map[string]bool)
+		for k, node := range nodes {
+			idx := node + off
+			if idx <= 0 || idx >= len(ao) {
+				fail("%s visits unknown destination %d", name, node)
+				continue
+			}
+			visitedBy[idx] = append(visitedBy[idx], i)
+			coord := lr.CoordianteMap.CoordinateToAddress[ao[idx]]
+			if k < len(v.Locations) && v.Locations[k] != coord {
+				fail("%s stop %d is at %s but its location is elsewhere", name, k+1, ao[idx])
+			}
+			stops[ao[idx]] = true
+			stopOrder = append(stopOrder, ao[idx])
+		}
+
+		dropped := make(map[string]bool)
+		for _, g := range v.Guests {
+			addr := g.Address
+			if idx := lr.AddressIndex(g.Coordinates); idx > 0 {
+				addr = ao[idx]
+			}
+			if !stops[addr] {
+				fail("%s carries %s but does not stop at %s", name, g.Name, g.Address)
+			}
+			dropped[addr] = true
+		}
+		for _, addr := range stopOrder {
+			if !dropped[addr] {
+				fail("%s stops at %s with nobody to drop off", name, addr)
+			}
+		}
+	}
+
+	// Grocery guests count as no people, so they are also counted by entry.
+	var keys []string
+	owed := make(map[string]int)
+	owedEntries := make(map[string]int)
+	names := make(map[string]string)
+	for _, g := range e.Guests {
+		key := assignmentKey(g)
+		if _, ok := owed[key]; !ok {
+			keys = append(keys, key)
+		}
+		owed[key] += g.GroupSize
+		owedEntries[key]++
+		names[key] = g.Name
+	}
+	carried := make(map[string]int)
+	carriedEntries := make(map[string]int)
+	for i := range rm.Vehicles {
+		for _, g := range rm.Vehicles[i].Guests {
+			carried[assignmentKey(g)] += g.GroupSize
+			carriedEntries[assignmentKey(g)]++
+			if _, ok := owed[assignmentKey(g)]; !ok {
+				fail("%s carries %s, who is not on the guest list", rm.Vehicles[i].DriverName(i), g.Name)
+			}
+		}
+	}
+	for _, g := range rm.Unassigned {
+		carried[assignmentKey(g)] += g.GroupSize
+		carriedEntries[assignmentKey(g)]++
+	}
+	for _, key := range keys {
+		n := owed[key]
+		switch {
+		case carried[key] < n:
+			fail("%d of %s's group of %d %s missing", n-carried[key], names[key], n, plural(n-carried[key], "is", "are"))
+		case carried[key] > n:
+			fail("%s is carried more than once", names[key])
+		case n == 0 && carriedEntries[key] < owedEntries[key]:
+			fail("%s is missing", names[key])
+		case n == 0 && carriedEntries[key] > owedEntries[key]:
+			fail("%s is carried more than once", names[key])
+		}
+	}
+
+	for idx := 1; idx < len(ao); idx++ {
+		served, ok := rm.ServedDestinations[idx]
+		vehicles := visitedBy[idx]
+		switch {
+		case !ok || served == -1:
+			if len(vehicles) > 0 {
+				fail("%s is visited but not marked as served", ao[idx])
+			}
+		case served < 0 || served >= len(rm.Vehicles):
+			fail("%s is served by unknown vehicle %d", ao[idx], served)
+		case !slices.Contains(vehicles, served):
+			fail("%s is marked as served by %s, which does not visit it", ao[idx], rm.Vehicles[served].DriverName(served))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: problems}
+}
@@ -0,0 +1,183 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+)
+
+// randomSizes returns group sizes as the sheets give them: dinners of one
+// to three people with the odd group too large for one vehicle, and
+// grocery deliveries, which count as no people.
+func randomSizes(rng *rand.Rand, eventType string) []int {
+	sizes := make([]int, 2+rng.Intn(10))
+	for i := range sizes {
+		switch {
+		case eventType == "Grocery":
+			sizes[i] = 0
+		case rng.Intn(8) == 0:
+			sizes[i] = 5 + rng.Intn(3)
+		default:
+			sizes[i] = 1 + rng.Intn(3)
+		}
+	}
+	return sizes
+}
+
+// onlyDispatchErrors fails unless err is nil or reports nothing but guests
+// the algorithm could not place.
+func onlyDispatchErrors(t *testing.T, step string, err error) {
+	t.Helper()
+	if err == nil {
+		return
+	}
+	var invalid *ValidationError
+	var dispatch *DispatchError
+	if errors.As(err, &invalid) || !errors.As(err, &dispatch) {
+		t.Fatalf("%s: %v", step, err)
+	}
+}
+
+func mustValidate(t *testing.T, step string, rm *RouteManager, lr *LocationRegistry, e *Event) {
+	t.Helper()
+	if err := rm.Validate(lr, e); err != nil {
+		t.Fatalf("%s: %v", step, err)
+	}
+}
+
+// randomGuest picks a carried or unassigned guest and where it rides.
+func randomGuest(rng *rand.Rand, rm *RouteManager) (Guest, int, bool) {
+	var guests []Guest
+	var from []int
+	for i := range rm.Vehicles {
+		for _, g := range rm.Vehicles[i].Guests {
+			guests = append(guests, g)
+			from = append(from, i)
+		}
+	}
+	for _, g := range rm.Unassigned {
+		guests = append(guests, g)
+		from = append(from, NoVehicle)
+	}
+	if len(guests) == 0 {
+		return Guest{}, 0, false
+	}
+	k := rng.Intn(len(guests))
+	return guests[k], from[k], true
+}
+
+func TestValidateAfterRouting(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, eventType := range []string{"Dinner", "Grocery"} {
+		for run := 0; run < 40; run++ {
+			t.Run(fmt.Sprintf("%s/%d", eventType, run), func(t *testing.T) {
+				lr, e := buildEvent(eventType, randomSizes(rng, eventType))
+
+				rm, err := OrchestateDispatch(lr, e, 0)
+				onlyDispatchErrors(t, "dispatch", err)
+				mustValidate(t, "dispatch", rm, lr, e)
+
+				warm, err := WarmStartDispatch(lr, e, rm.Assignments())
+				onlyDispatchErrors(t, "warm start", err)
+				mustValidate(t, "warm start", warm, lr, e)
+
+				// A late guest at a known address and one at a new address.
+				late := e.Guests[rng.Intn(len(e.Guests))]
+				for k, addr := range []string{late.Address, "99 Late St"} {
+					size := 0
+					if eventType == "Dinner" {
+						size = 1 + rng.Intn(3)
+					}
+					g := Guest{ID: fmt.Sprintf("late%d", k), Name: fmt.Sprintf("Late %d", k), GroupSize: size, Address: addr,
+						Coordinates: coordinates.GuestCoordinates{Long: DefaultDepot.Long + 0.01, Lat: DefaultDepot.Lat + 0.02}}
+					_, g.Coordinates, _ = lr.AddLocation(g.Address, g.Coordinates, g.GroupSize)
+					rebuildMatrix(lr)
+					rm.InsertGuest(g, e, lr)
+					mustValidate(t, "insert "+g.Name, rm, lr, e)
+				}
+
+				for move := 0; move < 30 && len(rm.Vehicles) > 0; move++ {
+					g, from, ok := randomGuest(rng, rm)
+					if !ok {
+						break
+					}
+					to := rng.Intn(len(rm.Vehicles))
+					split := rng.Intn(2) == 0
+					// A move the target has no seats for is refused.
+					if _, err := rm.MoveGuest(&g, from, to, rng.Intn(4), split, lr, eventType); err != nil {
+						continue
+					}
+					mustValidate(t, fmt.Sprintf("move %s from %d to %d", g.Name, from, to), rm, lr, e)
+				}
+			})
+		}
+	}
+}
+
+func TestValidateRejectsBrokenRoutes(t *testing.T) {
+	broken := map[string]struct {
+		problem string
+		breakIt func(rm *RouteManager, lr *LocationRegistry)
+	}{
+		"seats": {"seats remaining", func(rm *RouteManager, _ *LocationRegistry) {
+			rm.Vehicles[0].SeatsRemaining++
+		}},
+		"stop count": {"counts", func(rm *RouteManager, _ *LocationRegistry) {
+			rm.Vehicles[0].Route.DestinationCount++
+		}},
+		"guest in two vehicles": {"more than once", func(rm *RouteManager, _ *LocationRegistry) {
+			rm.Vehicles[1].Guests = append(rm.Vehicles[1].Guests, rm.Vehicles[0].Guests[0])
+		}},
+		"stale served destinations": {"marked as served", func(rm *RouteManager, lr *LocationRegistry) {
+			idx := lr.AddressIndex(rm.Vehicles[0].Guests[0].Coordinates)
+			rm.ServedDestinations[idx] = 1
+		}},
+	}
+
+	for _, eventType := range []string{"Dinner", "Grocery"} {
+		for name, tc := range broken {
+			sizes := []int{2, 2, 1, 1}
+			if eventType == "Grocery" {
+				sizes = []int{0, 0, 0, 0, 0, 0, 0, 0}
+			}
+			lr, e := buildEvent(eventType, sizes)
+			rm, err := OrchestateDispatch(lr, e, 0)
+			if err != nil {
+				t.Fatalf("%s: dispatch: %v", eventType, err)
+			}
+			if len(rm.Vehicles) < 2 {
+				t.Fatalf("%s: dispatch made %d vehicles, want at least two", eventType, len(rm.Vehicles))
+			}
+
+			tc.breakIt(rm, lr)
+			err = rm.Validate(lr, e)
+			var invalid *ValidationError
+			if !errors.As(err, &invalid) {
+				t.Errorf("%s %s: Validate = %v, want a *ValidationError", eventType, name, err)
+				continue
+			}
+			if !strings.Contains(err.Error(), tc.problem) {
+				t.Errorf("%s %s: Validate = %v, want a problem mentioning %q", eventType, name, err, tc.problem)
+			}
+		}
+	}
+}
+
+func TestLoadSessionValidatesSavedRoutes(t *testing.T) {
+	lr, e := buildEvent("Dinner", []int{2, 2, 1, 1})
+	rm, err := OrchestateDispatch(lr, e, 0)
+	if err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	rm.Vehicles[0].SeatsRemaining = maxVehicleSeats
+
+	s := roundTrip(t, Session{Event: *e, LocationRegistry: *lr, Routes: rm})
+	var invalid *ValidationError
+	if !errors.As(s.DispatchErr, &invalid) {
+		t.Errorf("DispatchErr = %v, want a *ValidationError", s.DispatchErr)
+	}
+}
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// NoVehicle is the vehicle index of guests no vehicle picked up. MoveGuest
+// takes it as the source to move a guest out of Unassigned.
+const NoVehicle = -1
+
+// DispatchError reports a routing run that did not place every guest in
+// exactly one vehicle. The routes are still usable: Unassigned guests are
+// left in RouteManager.Unassigned and Duplicated guests were removed from
+// all but their first vehicle.
+type DispatchError struct {
+	Unassigned []Guest
+	Duplicated []Guest
+	Err        error
+}
+
+func (e *DispatchError) Error() string {
+	var parts []string
+	if e.Err != nil {
+		parts = append(parts, e.Err.Error())
+	}
+	if len(e.Unassigned) > 0 {
+		parts = append(parts, fmt.Sprintf("%d %s could not be assigned to a vehicle: %s",
+			len(e.Unassigned), plural(len(e.Unassigned), "guest", "guests"), guestNames(e.Unassigned)))
+	}
+	if len(e.Duplicated) > 0 {
+		parts = append(parts, fmt.Sprintf("%d %s had been assigned twice: %s",
+			len(e.Duplicated), plural(len(e.Duplicated), "guest", "guests"), guestNames(e.Duplicated)))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (e *DispatchError) Unwrap() error {
+	return e.Err
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+func guestNames(guests []Guest) string {
+	names := make([]string, len(guests))
+	for i, g := range guests {
+		names[i] = g.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// assignmentKey identifies a guest across vehicles and the event.
+func assignmentKey(g Guest) string {
+	if g.ID != "" {
+		return "id:" + g.ID
+	}
+	return g.Name + "|" + g.Address
+}
+
+// verifyDispatch checks that every guest of e rides in exactly one vehicle,
+// counting the parts of a split group together. Guests, or parts of a
+// group, left behind are put in Unassigned and guests carried twice are
+// removed from the later vehicles. It returns a *DispatchError describing
+// what was wrong, wrapping err from the dispatch algorithm, or nil.
+func (rm *RouteManager) verifyDispatch(e *Event, lr *LocationRegistry, err error) error {
+	owed := make(map[string]int)
+	for _, g := range e.Guests {
+		owed[assignmentKey(g)] += g.GroupSize
+	}
+
+	var duplicated []Guest
+	for i := range rm.Vehicles {
+		v := &rm.Vehicles[i]
+		kept := v.Guests[:0]
+		for _, g := range v.Guests {
+			key := assignmentKey(g)
+			if owed[key] < g.GroupSize {
+				duplicated = append(duplicated, g)
+				v.SeatsRemaining += g.GroupSize
+				continue
+			}
+			owed[key] -= g.GroupSize
+			kept = append(kept, g)
+		}
+		if len(kept) < len(v.Guests) {
+			v.Guests = kept
+			v.UpdateRouteFromGuests(lr, e.EventType)
+		}
+	}
+
+	rm.Unassigned = nil
+	for _, g := range e.Guests {
+		key := assignmentKey(g)
+		left := owed[key]
+		if left <= 0 {
+			continue
+		}
+		if left > g.GroupSize {
+			left = g.GroupSize
+		}
+		if left < g.GroupSize {
+			g.PartyOf = g.GroupSize
+		}
+		g.GroupSize = left
+		owed[key] -= left
+		rm.Unassigned = append(rm.Unassigned, g)
+	}
+
+	if len(duplicated) > 0 {
+		rm.refreshServedDestinations(lr)
+	}
+	if err == nil && len(rm.Unassigned) == 0 && len(duplicated) == 0 {
+		return nil
+	}
+	return &DispatchError{Unassigned: rm.Unassigned, Duplicated: duplicated, Err: err}
+}
+
+// checkDispatch verifies a fresh dispatch and validates the result,
+// reporting both.
+func (rm *RouteManager) checkDispatch(e *Event, lr *LocationRegistry, err error) error {
+	err = rm.verifyDispatch(e, lr, err)
+	return errors.Join(err, rm.Validate(lr, e))
+}
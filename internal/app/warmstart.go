@@ -0,0 +1,279 @@
+package app
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxWarmDetour is the most distance, in metres, a returning guest may add
+// to their previous driver's route before they are placed afresh.
+const maxWarmDetour = 8000
+
+// Assignment is where a guest was delivered in an earlier event and by
+// whom. Vehicle orders the drivers as they were listed.
+type Assignment struct {
+	Driver  string
+	Address string
+	Vehicle int
+}
+
+// Baseline holds the assignments of an earlier event by BaselineKeys.
+type Baseline map[string]Assignment
+
+// GuestKey identifies a guest by name, for guests saved without an ID.
+func GuestKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// BaselineKeys lists the keys a guest is known by in a Baseline: the ID
+// when it has one, then the name.
+func BaselineKeys(id, name string) []string {
+	if id == "" {
+		return []string{GuestKey(name)}
+	}
+	return []string{"id:" + id, GuestKey(name)}
+}
+
+// Add records a guest's assignment under each of its keys. A name already
+// taken by another guest keeps its first assignment, while an ID takes the
+// latest.
+func (b Baseline) Add(id, name string, a Assignment) {
+	for _, key := range BaselineKeys(id, name) {
+		if _, taken := b[key]; !taken || strings.HasPrefix(key, "id:") {
+			b[key] = a
+		}
+	}
+}
+
+// lookup finds the assignment of g, by ID first and then by name.
+func (b Baseline) lookup(g Guest) (Assignment, bool) {
+	for _, key := range BaselineKeys(g.ID, g.Name) {
+		if a, ok := b[key]; ok {
+			return a, true
+		}
+	}
+	return Assignment{}, false
+}
+
+func sameAddress(a, b string) bool {
+	return GuestKey(a) == GuestKey(b)
+}
+
+// Assignments returns the current driver of every routed guest. A group
+// split between vehicles is assigned to the vehicle carrying most of it, the
+// first of them on a tie.
+func (rm *RouteManager) Assignments() Baseline {
+	type part struct {
+		vehicle int
+		guest   Guest
+	}
+	var order []string
+	largest := make(map[string]part)
+	for i := range rm.Vehicles {
+		for _, g := range rm.Vehicles[i].Guests {
+			key := assignmentKey(g)
+			p, seen := largest[key]
+			if !seen {
+				order = append(order, key)
+			}
+			if !seen || g.GroupSize > p.guest.GroupSize {
+				largest[key] = part{i, g}
+			}
+		}
+	}
+
+	b := make(Baseline)
+	for _, key := range order {
+		p := largest[key]
+		b.Add(p.guest.ID, p.guest.Name, Assignment{Driver: rm.Vehicles[p.vehicle].DriverName(p.vehicle), Address: p.guest.Address, Vehicle: p.vehicle})
+	}
+	return b
+}
+
+// drivers lists the drivers of the baseline in their original order.
+func (b Baseline) drivers() []string {
+	first := make(map[string]int)
+	for _, a := range b {
+		if at, ok := first[a.Driver]; !ok || a.Vehicle < at {
+			first[a.Driver] = a.Vehicle
+		}
+	}
+
+	drivers := make([]string, 0, len(first))
+	for d := range first {
+		drivers = append(drivers, d)
+	}
+	sort.Slice(drivers, func(i, j int) bool {
+		if first[drivers[i]] != first[drivers[j]] {
+			return first[drivers[i]] < first[drivers[j]]
+		}
+		return drivers[i] < drivers[j]
+	})
+	return drivers
+}
+
+// Change is how a guest's delivery differs from the baseline.
+type Change int
+
+const (
+	Unchanged Change = iota
+	ChangeNew
+	ChangeAddress
+	ChangeDriver
+)
+
+// GuestChange describes how the guest on the vehicle at vehicleIndex
+// differs from the baseline the routes were started from, with a label to
+// show for it, or returns Unchanged and "" when nothing changed or there is
+// no baseline. Part of a split group is unchanged while another part still
+// rides with the baseline driver.
+func (rm *RouteManager) GuestChange(g Guest, vehicleIndex int) (Change, string) {
+	if rm.Previous == nil || vehicleIndex < 0 || vehicleIndex >= len(rm.Vehicles) {
+		return Unchanged, ""
+	}
+	a, ok := rm.Previous.lookup(g)
+	switch {
+	case !ok:
+		return ChangeNew, "New"
+	case !sameAddress(a.Address, g.Address):
+		return ChangeAddress, "New address"
+	case a.Driver != rm.Vehicles[vehicleIndex].DriverName(vehicleIndex) && !rm.partWithDriver(g, a.Driver):
+		return ChangeDriver, "Was " + a.Driver
+	}
+	return Unchanged, ""
+}
+
+// partWithDriver reports whether part of split group g rides with driver.
+func (rm *RouteManager) partWithDriver(g Guest, driver string) bool {
+	if g.PartyOf == 0 {
+		return false
+	}
+	for i := range rm.Vehicles {
+		if rm.Vehicles[i].DriverName(i) != driver {
+			continue
+		}
+		for _, other := range rm.Vehicles[i].Guests {
+			if assignmentKey(other) == assignmentKey(g) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WarmStartDispatch routes the event starting from an earlier event's
+// assignments. Destinations whose returning guests fit their previous
+// driver's vehicle stay with that driver; new guests, guests who moved and
+// guests that no longer fit are then placed at the cheapest position, on
+// new vehicles when no driver has room. Dinner groups too large for one
+// vehicle fill vehicles of their own before the rest is placed. Errors are
+// reported as by OrchestateDispatch.
+func WarmStartDispatch(lr *LocationRegistry, e *Event, prev Baseline) (*RouteManager, error) {
+	rm := newRouteManager(lr)
+	rm.Algorithm = "Warm start"
+	rm.Previous = prev
+	off := routeOffset(e.EventType)
+
+	vehicleOf := make(map[string]int)
+	for _, d := range prev.drivers() {
+		vehicleOf[d] = len(rm.Vehicles)
+		rm.Vehicles = append(rm.Vehicles, Vehicle{SeatsRemaining: maxVehicleSeats, Driver: d})
+	}
+
+	guestsAt := make(map[int][]Guest)
+	for _, g := range e.Guests {
+		if idx := lr.AddressIndex(g.Coordinates); idx > 0 {
+			guestsAt[idx] = append(guestsAt[idx], g)
+		}
+	}
+
+	var pending []int
+	for idx := 1; idx < len(lr.CoordianteMap.AddressOrder); idx++ {
+		guests := guestsAt[idx]
+		if len(guests) == 0 {
+			continue
+		}
+
+		people := 0
+		votes := make(map[string]int)
+		for _, g := range guests {
+			people += g.GroupSize
+			if a, ok := prev.lookup(g); ok && sameAddress(a.Address, g.Address) {
+				votes[a.Driver] += g.GroupSize
+			}
+		}
+
+		driver, best := "", 0
+		for d, n := range votes {
+			if n > best || (n == best && d < driver) {
+				driver, best = d, n
+			}
+		}
+		if driver == "" {
+			pending = append(pending, idx)
+			continue
+		}
+
+		v := &rm.Vehicles[vehicleOf[driver]]
+		if v.SeatsRemaining < people || v.Route.DestinationCount >= maxRouteStops {
+			pending = append(pending, idx)
+			continue
+		}
+		cost, pos := v.insertionCost(idx, off, lr)
+		if v.Route.DestinationCount > 0 && cost > maxWarmDetour {
+			pending = append(pending, idx)
+			continue
+		}
+		v.addGuestsAt(pos, guests, lr, e.EventType)
+	}
+
+	kept := rm.Vehicles[:0]
+	for _, v := range rm.Vehicles {
+		if len(v.Guests) == 0 {
+			continue
+		}
+		if v.Driver == DriverLabel(len(kept)) {
+			v.Driver = ""
+		}
+		kept = append(kept, v)
+	}
+	rm.Vehicles = kept
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		return lr.distance(0, pending[i]) > lr.distance(0, pending[j])
+	})
+	for _, idx := range pending {
+		guests := guestsAt[idx]
+		if e.EventType == "Dinner" {
+			guests = rm.splitOversized(guests, lr, e.EventType)
+		}
+		people := 0
+		for _, g := range guests {
+			people += g.GroupSize
+		}
+
+		vi, pos := rm.cheapestInsertion(idx, people, e.EventType, lr)
+		if vi == -1 {
+			rm.AddNewVehicle()
+			vi, pos = len(rm.Vehicles)-1, 0
+		}
+		rm.Vehicles[vi].addGuestsAt(pos, guests, lr, e.EventType)
+	}
+
+	rm.refreshServedDestinations(lr)
+	return rm, rm.checkDispatch(e, lr, nil)
+}
+
+// addGuestsAt inserts guests into v's guest list at pos and rebuilds its
+// route.
+func (v *Vehicle) addGuestsAt(pos int, guests []Guest, lr *LocationRegistry, eventType string) {
+	updated := make([]Guest, 0, len(v.Guests)+len(guests))
+	updated = append(updated, v.Guests[:pos]...)
+	updated = append(updated, guests...)
+	updated = append(updated, v.Guests[pos:]...)
+	v.Guests = updated
+	for _, g := range guests {
+		v.SeatsRemaining -= g.GroupSize
+	}
+	v.UpdateRouteFromGuests(lr, eventType)
+}
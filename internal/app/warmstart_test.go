@@ -0,0 +1,46 @@
+package app
+
+import "testing"
+
+func TestGuestChange(t *testing.T) {
+	lr, e := buildEvent("Dinner", []int{2, 2, 1, 1})
+	rm, err := OrchestateDispatch(lr, e, 0)
+	if err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	if change, label := rm.GuestChange(e.Guests[0], 0); change != Unchanged || label != "" {
+		t.Errorf("without a baseline = %v %q, want no change", change, label)
+	}
+
+	rm.Previous = rm.Assignments()
+	vi := 0
+	for len(rm.Vehicles[vi].Guests) == 0 {
+		vi++
+	}
+	g := rm.Vehicles[vi].Guests[0]
+	other := (vi + 1) % len(rm.Vehicles)
+
+	moved := g
+	moved.Address = "9 Oak St"
+	newcomer := g
+	newcomer.ID, newcomer.Name = "g9", "Guest 9"
+
+	tests := []struct {
+		name    string
+		guest   Guest
+		vehicle int
+		change  Change
+		label   string
+	}{
+		{"same driver", g, vi, Unchanged, ""},
+		{"other driver", g, other, ChangeDriver, "Was " + rm.Vehicles[vi].DriverName(vi)},
+		{"new address", moved, vi, ChangeAddress, "New address"},
+		{"new guest", newcomer, vi, ChangeNew, "New"},
+		{"no vehicle", newcomer, NoVehicle, Unchanged, ""},
+	}
+	for _, tt := range tests {
+		if change, label := rm.GuestChange(tt.guest, tt.vehicle); change != tt.change || label != tt.label {
+			t.Errorf("%s: GuestChange = %v %q, want %v %q", tt.name, change, label, tt.change, tt.label)
+		}
+	}
+}
@@ -13,7 +13,22 @@ var clientSecretJSON []byte
 var mapsConfigJSON []byte
 
 type MapsConfig struct {
-	MapsAPIKey string `json:"maps_api_key"`
+	MapsAPIKey  string             `json:"maps_api_key"`
+	ServiceArea *ServiceAreaConfig `json:"service_area,omitempty"`
+}
+
+// ServiceAreaConfig overrides the default Ottawa service area. Zero fields
+// keep their default value.
+type ServiceAreaConfig struct {
+	South         float64 `json:"south"`
+	West          float64 `json:"west"`
+	North         float64 `json:"north"`
+	East          float64 `json:"east"`
+	DepotLat      float64 `json:"depot_lat"`
+	DepotLng      float64 `json:"depot_lng"`
+	MaxDistanceKm float64 `json:"max_distance_km"`
+	Components    string  `json:"components"`
+	AddressSuffix string  `json:"address_suffix"`
 }
 
 type GoogleServiceAccount struct {
@@ -64,3 +79,11 @@ func GetEmbeddedMapsConfig() (*MapsConfig, error) {
 	}
 	return &config, nil
 }
+
+func GetEmbeddedServiceArea() (*ServiceAreaConfig, error) {
+	config, err := GetEmbeddedMapsConfig()
+	if err != nil {
+		return nil, err
+	}
+	return config.ServiceArea, nil
+}
@@ -15,10 +15,15 @@ func MapDatabaseEventToHttp(dbEvent *database.Event) *geoapi.Event {
 	for _, g := range (*dbEvent).Guests {
 		convertedGuest := geoapi.Guest{
 			Status:      geoapi.GuestStatus(g.Status),
+			ID:          g.ID,
 			Name:        g.Name,
 			GroupSize:   g.GroupSize,
 			Address:     g.Address,
 			PhoneNumber: g.PhoneNumber,
+			Unit:        g.Unit,
+			Notes:       g.Notes,
+			Extra:       g.Extra,
+			Row:         g.Row,
 		}
 		httpGuests = append(httpGuests, convertedGuest)
 	}
@@ -34,14 +39,7 @@ func MapDatabaseGeoEventToApp(geoEvent *geoapi.Event) (*app.Event, *app.Location
 	appGuests := make([]app.Guest, 0, numGuests)
 
 	for _, g := range geoEvent.Guests {
-		convertedGuest := app.Guest{
-			Name:        g.Name,
-			GroupSize:   g.GroupSize,
-			Coordinates: g.Coordinates,
-			Address:     g.Address,
-			PhoneNumber: g.PhoneNumber,
-		}
-		appGuests = append(appGuests, convertedGuest)
+		appGuests = append(appGuests, MapGeoGuestToApp(g))
 	}
 
 	
@@ -58,5 +56,51 @@ func MapDatabaseGeoEventToApp(geoEvent *geoapi.Event) (*app.Event, *app.Location
 		}, &app.LocationRegistry{
 			DistanceMatrix: geoEvent.GuestLocations.DistanceMatrix,
 			CoordianteMap:  appCoordMap,
+			Depot:          geoEvent.GuestLocations.Depot,
+		}
+}
+
+func MapGeoGuestToApp(g geoapi.Guest) app.Guest {
+	return app.Guest{
+		ID:          g.ID,
+		Name:        g.Name,
+		GroupSize:   g.GroupSize,
+		Coordinates: g.Coordinates,
+		Address:     g.Address,
+		PhoneNumber: g.PhoneNumber,
+		Unit:        g.Unit,
+		Notes:       g.Notes,
+		Extra:       g.Extra,
+		Row:         g.Row,
+	}
+}
+
+// MapRoutesToDatabase lists each vehicle's guests in stop order for writing
+// back to the guest sheet.
+func MapRoutesToDatabase(rm *app.RouteManager, lr *app.LocationRegistry) []database.VehicleAssignment {
+	vehicles := make([]database.VehicleAssignment, 0, len(rm.Vehicles))
+	for i := range rm.Vehicles {
+		v := &rm.Vehicles[i]
+		stopNumbers := v.StopNumbers()
+		stops := make([]database.StopAssignment, len(v.Guests))
+		for j, g := range v.Guests {
+			stops[j] = database.StopAssignment{
+				ID:          g.ID,
+				Row:         g.Row,
+				Stop:        stopNumbers[j],
+				Name:        g.Name,
+				Address:     g.Address,
+				Unit:        g.Unit,
+				PhoneNumber: g.PhoneNumber,
+				GroupSize:   g.GroupSize,
+				Notes:       g.Notes,
+			}
 		}
+		vehicles = append(vehicles, database.VehicleAssignment{
+			Driver:     v.DriverName(i),
+			Stops:      stops,
+			Directions: v.DirectionsURLs(lr.Depot),
+		})
+	}
+	return vehicles
 }
@@ -1,6 +1,11 @@
 package coordinates
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
+
+const earthRadiusKm = 6371.0
 
 type GuestCoordinates struct {
 	Long float64
@@ -10,3 +15,15 @@ type GuestCoordinates struct {
 func (gc *GuestCoordinates) ToString() string {
 	return fmt.Sprintf("%f,%f;", gc.Long, gc.Lat)
 }
+
+// DistanceKm returns the great-circle distance between gc and other.
+func (gc *GuestCoordinates) DistanceKm(other GuestCoordinates) float64 {
+	lat1 := gc.Lat * math.Pi / 180
+	lat2 := other.Lat * math.Pi / 180
+	dLat := lat2 - lat1
+	dLong := (other.Long - gc.Long) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLong/2)*math.Sin(dLong/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
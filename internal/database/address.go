@@ -0,0 +1,70 @@
+package database
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	parenthesisedNote = regexp.MustCompile(`\(([^)]*)\)`)
+	buzzerPattern     = regexp.MustCompile(`(?i)[,;\s]*\b(?:buzz(?:er)?|enter ?code|door ?code)\b\s*(?:code|#|no\.?)?\s*:?\s*([\w#*-]+)`)
+	unitPattern       = regexp.MustCompile(`(?i)[,;\s]*(?:\b(?:apt|apartment|unit|suite)\b\.?|#)\s*(?:#|no\.?)?\s*([a-z]?\d+[a-z]?|[a-z])\b`)
+	unitPrefix        = regexp.MustCompile(`^\s*#?\s*([a-zA-Z]?\d{1,5}[a-zA-Z]?)\s*-\s*(\d+[a-zA-Z]?\s+\S.*)$`)
+	noteSeparator     = regexp.MustCompile(`\s+-\s+|;`)
+)
+
+// AddressParts is a sheet address split into the part sent to the geocoder
+// and the details only the driver needs.
+type AddressParts struct {
+	Street string
+	Unit   string
+	Notes  string
+}
+
+// SplitAddress separates the street address from unit numbers, buzzer codes
+// and free-form notes that volunteers add to the address cell, e.g.
+// "304-123 Main St (side door), buzz 1234".
+func SplitAddress(raw string) AddressParts {
+	var parts AddressParts
+	notes := make([]string, 0)
+	address := strings.TrimSpace(raw)
+
+	for _, m := range parenthesisedNote.FindAllStringSubmatch(address, -1) {
+		if note := strings.TrimSpace(m[1]); note != "" {
+			notes = append(notes, note)
+		}
+	}
+	address = parenthesisedNote.ReplaceAllString(address, " ")
+
+	// A unit written before the street, as in "304-123 Main St" or
+	// "#5 - 10 Elm", is taken first so its dash is not read as a note.
+	if m := unitPrefix.FindStringSubmatch(address); m != nil {
+		parts.Unit = m[1]
+		address = m[2]
+	}
+
+	if sections := noteSeparator.Split(address, -1); len(sections) > 1 {
+		address = sections[0]
+		for _, extra := range sections[1:] {
+			if extra = strings.TrimSpace(extra); extra != "" {
+				notes = append(notes, extra)
+			}
+		}
+	}
+
+	if m := buzzerPattern.FindStringSubmatch(address); m != nil {
+		notes = append(notes, "Buzzer "+m[1])
+		address = strings.Replace(address, m[0], " ", 1)
+	}
+
+	if parts.Unit == "" {
+		if m := unitPattern.FindStringSubmatch(address); m != nil {
+			parts.Unit = strings.ToUpper(m[1])
+			address = strings.Replace(address, m[0], " ", 1)
+		}
+	}
+
+	parts.Street = strings.Trim(strings.Join(strings.Fields(address), " "), " ,")
+	parts.Notes = strings.Join(notes, "; ")
+	return parts
+}
@@ -0,0 +1,37 @@
+package database
+
+import "testing"
+
+func TestSplitAddress(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want AddressParts
+	}{
+		{"304-123 Main St (side door), buzz 1234", AddressParts{"123 Main St", "304", "side door; Buzzer 1234"}},
+		{"123 Main St Apt 4", AddressParts{"123 Main St", "4", ""}},
+		{"#5 - 10 Elm", AddressParts{"10 Elm", "5", ""}},
+		{"#5 10 Elm St", AddressParts{"10 Elm St", "5", ""}},
+		{"12A-45 King St", AddressParts{"45 King St", "12A", ""}},
+		{"Apt 4 123 Main St", AddressParts{"123 Main St", "4", ""}},
+		{"10 Elm St Unit b", AddressParts{"10 Elm St", "B", ""}},
+		{"10 Elm St, Suite 200", AddressParts{"10 Elm St", "200", ""}},
+		{"10 Elm St apt. #12", AddressParts{"10 Elm St", "12", ""}},
+		{"304-123 Main St - back door", AddressParts{"123 Main St", "304", "back door"}},
+		{"10 Elm St - call on arrival", AddressParts{"10 Elm St", "", "call on arrival"}},
+		{"10 Elm St; leave at door", AddressParts{"10 Elm St", "", "leave at door"}},
+		{"10 Elm St, buzzer code 12#", AddressParts{"10 Elm St", "", "Buzzer 12#"}},
+		{"10 Elm St (blue house) (dog)", AddressParts{"10 Elm St", "", "blue house; dog"}},
+
+		// Addresses without details stay as they are.
+		{"123 Main St", AddressParts{"123 Main St", "", ""}},
+		{"1010 Bank St", AddressParts{"1010 Bank St", "", ""}},
+		{"123 Main St, Ottawa, ON K1A 0B1", AddressParts{"123 Main St, Ottawa, ON K1A 0B1", "", ""}},
+		{"  123   Main St  ", AddressParts{"123 Main St", "", ""}},
+		{"", AddressParts{}},
+	}
+	for _, tt := range tests {
+		if got := SplitAddress(tt.raw); got != tt.want {
+			t.Errorf("SplitAddress(%q) = %+v, want %+v", tt.raw, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/Iwark/spreadsheet.v2"
+)
+
+const (
+	columnStatus    = "Status"
+	columnName      = "Name"
+	columnGroupSize = "Group Size"
+	columnNumber    = "Number"
+	columnAddress   = "Address"
+	columnID        = "ID"
+)
+
+var requiredColumns = []string{columnStatus, columnName, columnGroupSize, columnNumber, columnAddress}
+
+// columnAliases lists the header titles accepted for each column, compared
+// case-insensitively, best first. Bare words such as "Group" or "Contact"
+// are left out, as sheets use them for unrelated columns.
+var columnAliases = map[string][]string{
+	columnStatus:    {"Status", "RSVP", "Confirmation"},
+	columnName:      {"Name", "Guest", "Guest Name", "Full Name"},
+	columnGroupSize: {"Group Size", "Size", "People", "Party Size", "# of People"},
+	columnNumber:    {"Number", "Phone", "Phone Number", "Cell", "Telephone"},
+	columnAddress:   {"Address", "Street Address", "Home Address"},
+	columnID:        {"ID", "Guest ID", "Client ID", "Household ID"},
+}
+
+// columnMap locates the known columns of a sheet by header title. Every other
+// titled column is kept as an extra and passed through to the guest.
+type columnMap struct {
+	index  map[string]int
+	extras map[string]int
+}
+
+func normalizeHeader(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
+
+// mapColumns builds the column lookup for a header row, reporting every
+// required column it could not find. When several headers match a column,
+// the one earliest in its alias list wins, then the leftmost.
+func mapColumns(header []string) (columnMap, error) {
+	cm := columnMap{index: make(map[string]int), extras: make(map[string]int)}
+
+	type alias struct {
+		column string
+		rank   int
+	}
+	aliasOf := make(map[string]alias)
+	for column, aliases := range columnAliases {
+		for rank, a := range aliases {
+			aliasOf[normalizeHeader(a)] = alias{column, rank}
+		}
+	}
+	ranks := make(map[string]int)
+	output := make(map[string]bool)
+	for _, aliases := range outputColumns {
+		for _, alias := range aliases {
+			output[normalizeHeader(alias)] = true
+		}
+	}
+
+	for i, title := range header {
+		key := normalizeHeader(title)
+		if key == "" || output[key] {
+			continue
+		}
+		if a, ok := aliasOf[key]; ok {
+			if rank, seen := ranks[a.column]; !seen || a.rank < rank {
+				cm.index[a.column] = i
+				ranks[a.column] = a.rank
+			}
+			continue
+		}
+		if _, seen := cm.extras[strings.TrimSpace(title)]; !seen {
+			cm.extras[strings.TrimSpace(title)] = i
+		}
+	}
+
+	missing := make([]string, 0)
+	for _, column := range requiredColumns {
+		if _, ok := cm.index[column]; !ok {
+			missing = append(missing, fmt.Sprintf("%q", column))
+		}
+	}
+	if len(missing) > 0 {
+		return cm, fmt.Errorf("missing required headers: %s (found: %s)",
+			strings.Join(missing, ", "), strings.Join(nonEmpty(header), ", "))
+	}
+
+	return cm, nil
+}
+
+// value returns the cell of row in column, or "" when the row is shorter.
+func (cm columnMap) value(row []string, column string) string {
+	i, ok := cm.index[column]
+	if !ok || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+// extraValues collects the non-empty cells of the extra columns of row.
+func (cm columnMap) extraValues(row []string) map[string]string {
+	if len(cm.extras) == 0 {
+		return nil
+	}
+	values := make(map[string]string)
+	for title, i := range cm.extras {
+		if i < len(row) && strings.TrimSpace(row[i]) != "" {
+			values[title] = strings.TrimSpace(row[i])
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+func rowValues(row []spreadsheet.Cell) []string {
+	values := make([]string, len(row))
+	for i, cell := range row {
+		values[i] = cell.Value
+	}
+	return values
+}
+
+func nonEmpty(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			result = append(result, fmt.Sprintf("%q", strings.TrimSpace(v)))
+		}
+	}
+	return result
+}
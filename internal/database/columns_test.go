@@ -0,0 +1,86 @@
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMapColumns(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []string
+		want   map[string]int
+		extras []string
+	}{
+		{
+			name:   "primary names",
+			header: []string{"Status", "Name", "Group Size", "Number", "Address"},
+			want:   map[string]int{columnStatus: 0, columnName: 1, columnGroupSize: 2, columnNumber: 3, columnAddress: 4},
+		},
+		{
+			name:   "aliases in any order and case",
+			header: []string{"  phone number ", "RSVP", "Street Address", "Guest Name", "party  size", "Client ID"},
+			want:   map[string]int{columnNumber: 0, columnStatus: 1, columnAddress: 2, columnName: 3, columnGroupSize: 4, columnID: 5},
+		},
+		{
+			name:   "primary name beats an earlier alias",
+			header: []string{"Phone", "Status", "Name", "People", "Group Size", "Number", "Address"},
+			want:   map[string]int{columnNumber: 5, columnStatus: 1, columnName: 2, columnGroupSize: 4, columnAddress: 6},
+		},
+		{
+			name:   "leftmost of equal headers",
+			header: []string{"Status", "Name", "Size", "Number", "Address", "Size"},
+			want:   map[string]int{columnStatus: 0, columnName: 1, columnGroupSize: 2, columnNumber: 3, columnAddress: 4},
+		},
+		{
+			name:   "unrelated group and contact columns",
+			header: []string{"Group", "Contact", "Status", "Name", "Group Size", "Phone", "Address", "Dietary"},
+			want:   map[string]int{columnStatus: 2, columnName: 3, columnGroupSize: 4, columnNumber: 5, columnAddress: 6},
+			extras: []string{"Group", "Contact", "Dietary"},
+		},
+		{
+			name:   "output columns are neither known nor extra",
+			header: []string{"Status", "Name", "Group Size", "Number", "Address", "Driver"},
+			want:   map[string]int{columnStatus: 0, columnName: 1, columnGroupSize: 2, columnNumber: 3, columnAddress: 4},
+		},
+	}
+	for _, tt := range tests {
+		cm, err := mapColumns(tt.header)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if len(cm.index) != len(tt.want) {
+			t.Errorf("%s: index = %v, want %v", tt.name, cm.index, tt.want)
+		}
+		for column, i := range tt.want {
+			if got, ok := cm.index[column]; !ok || got != i {
+				t.Errorf("%s: %s at %d, want %d", tt.name, column, got, i)
+			}
+		}
+		if len(cm.extras) != len(tt.extras) {
+			t.Errorf("%s: extras = %v, want %v", tt.name, cm.extras, tt.extras)
+		}
+		for _, title := range tt.extras {
+			if _, ok := cm.extras[title]; !ok {
+				t.Errorf("%s: %q is not an extra column", tt.name, title)
+			}
+		}
+	}
+}
+
+func TestMapColumnsMissing(t *testing.T) {
+	_, err := mapColumns([]string{"Name", "Group", "Contact", "Address", ""})
+	if err == nil {
+		t.Fatal("mapColumns accepted a header without Status, Group Size or Number")
+	}
+	for _, want := range []string{`"Status"`, `"Group Size"`, `"Number"`, `found: "Name", "Group", "Contact", "Address"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+	missing, _, _ := strings.Cut(err.Error(), "(found")
+	if strings.Contains(missing, `"Name"`) || strings.Contains(missing, `"Address"`) {
+		t.Errorf("error %q reports a column it found as missing", err)
+	}
+}
@@ -0,0 +1,237 @@
+package database
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// similarity is how alike two names or addresses must be, from 0 to 1, for
+// the rows to be flagged as a possible duplicate.
+const similarity = 0.8
+
+// Duplicate is a pair of guests that look like the same household. Keep and
+// Drop index Event.Guests; Keep is the earlier row unless only the later one
+// is routed.
+type Duplicate struct {
+	Keep   int
+	Drop   int
+	Reason string
+}
+
+// addressWords shortens common street words so "Main Street" and "Main St"
+// compare equal.
+var addressWords = map[string]string{
+	"street": "st", "avenue": "ave", "road": "rd", "drive": "dr", "crescent": "cres",
+	"boulevard": "blvd", "court": "crt", "place": "pl", "lane": "ln", "private": "pvt",
+}
+
+// hashID derives a guest ID from the name, address and unit, so a guest
+// keeps the same ID from week to week when the sheet has no ID column. The
+// ID is only as stable as those cells: correcting a typo in the name or
+// address gives the guest a new ID, so earlier runs compared as a baseline
+// show them as new and their old history no longer matches. Sheets that
+// need IDs to survive corrections should add an ID column.
+func hashID(g Guest) string {
+	sum := sha1.Sum([]byte(guestKey(g)))
+	return "g-" + hex.EncodeToString(sum[:5])
+}
+
+// assignIDs gives every guest an ID unique within the event. Guests that
+// share an ID, such as two family members with the same name, get a suffix
+// in row order; a repeated ID from the sheet is reported.
+func assignIDs(guests []Guest, report *ImportReport) {
+	rowOf := make(map[string]int)
+	for i := range guests {
+		g := &guests[i]
+		fromSheet := g.ID != ""
+		if !fromSheet {
+			g.ID = hashID(*g)
+		}
+
+		id := g.ID
+		for n := 2; ; n++ {
+			if _, taken := rowOf[id]; !taken {
+				break
+			}
+			id = fmt.Sprintf("%s-%d", g.ID, n)
+		}
+		if id != g.ID && fromSheet {
+			report.add(g.Row, g.Name, IssueWarning, "ID %q is also used by row %d", g.ID, rowOf[g.ID])
+		}
+		g.ID = id
+		rowOf[id] = g.Row
+	}
+}
+
+// findDuplicates flags guests that look like an earlier row: a similar name
+// with the same address or phone number, or at a similar address. Different
+// names sharing a phone and address are a household, not a duplicate, and
+// guests in different units of a building are never flagged.
+func findDuplicates(guests []Guest, report *ImportReport) []Duplicate {
+	var dups []Duplicate
+	for j := range guests {
+		for i := 0; i < j; i++ {
+			reason := duplicateReason(guests[i], guests[j])
+			if reason == "" {
+				continue
+			}
+			keep, drop := i, j
+			if guests[j].routed() && !guests[i].routed() {
+				keep, drop = j, i
+			}
+			dups = append(dups, Duplicate{Keep: keep, Drop: drop, Reason: reason})
+			report.add(guests[drop].Row, guests[drop].Name, IssueWarning, "possible duplicate of row %d (%s)", guests[keep].Row, reason)
+			break
+		}
+	}
+	return dups
+}
+
+// routed reports whether the guest's status puts them on a route.
+func (g Guest) routed() bool {
+	return g.Status == Confirmed || g.Status == GroceryOnly
+}
+
+func duplicateReason(a, b Guest) string {
+	if a.Unit != "" && b.Unit != "" && normalizeHeader(a.Unit) != normalizeHeader(b.Unit) {
+		return ""
+	}
+	if guestKey(a) == guestKey(b) {
+		return "same name and address"
+	}
+
+	if !similar(normalizeName(a.Name), normalizeName(b.Name)) {
+		return ""
+	}
+	phone := normalizePhone(a.PhoneNumber)
+	switch {
+	case phone != "" && phone == normalizePhone(b.PhoneNumber):
+		return "similar name and same phone number"
+	case similar(normalizeAddress(a.Address), normalizeAddress(b.Address)):
+		return "similar name and address"
+	}
+	return ""
+}
+
+// normalizePhone keeps the last ten digits of a phone number, or returns ""
+// when it is too short to compare.
+func normalizePhone(phone string) string {
+	digits := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, phone)
+	if len(digits) < 7 {
+		return ""
+	}
+	if len(digits) > 10 {
+		digits = digits[len(digits)-10:]
+	}
+	return digits
+}
+
+func normalizeName(name string) string {
+	return strings.Join(words(name), " ")
+}
+
+func normalizeAddress(address string) string {
+	ws := words(address)
+	for i, w := range ws {
+		if short, ok := addressWords[w]; ok {
+			ws[i] = short
+		}
+	}
+	return strings.Join(ws, " ")
+}
+
+// words lower-cases s and splits it on anything that is not a letter or
+// digit.
+func words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// similar reports whether a and b differ by at most a fifth of their length
+// in single character edits.
+func similar(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	return 1-float64(editDistance(ra, rb))/float64(longest) >= similarity
+}
+
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// MergeDuplicates folds each dropped guest into the guest it duplicates:
+// blank details are filled in from the dropped row, the larger group size is
+// kept and the dropped row is removed from the event. The merges are kept in
+// Merged so a recorded run can apply them again.
+func (e *Event) MergeDuplicates(dups []Duplicate) {
+	into := make(map[int]int)
+	for _, d := range dups {
+		keep := d.Keep
+		for {
+			next, dropped := into[keep]
+			if !dropped {
+				break
+			}
+			keep = next
+		}
+		if _, done := into[d.Drop]; done || keep == d.Drop {
+			continue
+		}
+		into[d.Drop] = keep
+		e.Merged = append(e.Merged, d)
+
+		k, drop := &e.Guests[keep], e.Guests[d.Drop]
+		if drop.GroupSize > k.GroupSize {
+			k.GroupSize = drop.GroupSize
+		}
+		if k.PhoneNumber == "" {
+			k.PhoneNumber = drop.PhoneNumber
+		}
+		if k.Unit == "" {
+			k.Unit = drop.Unit
+		}
+		if k.Notes == "" {
+			k.Notes = drop.Notes
+		}
+	}
+
+	guests := make([]Guest, 0, len(e.Guests)-len(into))
+	for i, g := range e.Guests {
+		if _, dropped := into[i]; !dropped {
+			guests = append(guests, g)
+		}
+	}
+	e.Guests = guests
+	e.Duplicates = nil
+}
@@ -0,0 +1,45 @@
+package database
+
+import "testing"
+
+func TestDuplicateReason(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Guest
+		want string
+	}{
+		{"same row twice",
+			Guest{Name: "Mina Tawfik", Address: "1 Main Street"},
+			Guest{Name: "mina  tawfik", Address: "1 main street"},
+			"same name and address"},
+		{"typo in name and address",
+			Guest{Name: "Mina Tawfik", Address: "12 Parkdale Avenue"},
+			Guest{Name: "Mina Tawfick", Address: "12 Parkdale Ave"},
+			"similar name and address"},
+		{"same phone, moved",
+			Guest{Name: "Mina Tawfik", Address: "1 Main St", PhoneNumber: "613-555-0101"},
+			Guest{Name: "Mina Tawfik", Address: "80 Elm Cres", PhoneNumber: "(613) 555 0101"},
+			"similar name and same phone number"},
+		{"household sharing a phone",
+			Guest{Name: "Mina Tawfik", Address: "1 Main St", PhoneNumber: "613-555-0101"},
+			Guest{Name: "Sara Girgis", Address: "1 Main Street", PhoneNumber: "613-555-0101"},
+			""},
+		{"different names, same phone",
+			Guest{Name: "Mina Tawfik", Address: "1 Main St", PhoneNumber: "613-555-0101"},
+			Guest{Name: "Sara Girgis", Address: "9 Elm St", PhoneNumber: "613-555-0101"},
+			""},
+		{"same name elsewhere",
+			Guest{Name: "Mina Tawfik", Address: "1 Main St"},
+			Guest{Name: "Mina Tawfik", Address: "250 Bank St"},
+			""},
+		{"different units",
+			Guest{Name: "Mina Tawfik", Address: "1 Main St", Unit: "4"},
+			Guest{Name: "Mina Tawfik", Address: "1 Main St", Unit: "12"},
+			""},
+	}
+	for _, tt := range tests {
+		if got := duplicateReason(tt.a, tt.b); got != tt.want {
+			t.Errorf("%s: duplicateReason = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
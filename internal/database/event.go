@@ -3,50 +3,91 @@ package database
 import (
 	"fmt"
 	"strings"
-
-	"gopkg.in/Iwark/spreadsheet.v2"
 )
 
 
 type Event struct {
-	Guests    []Guest 
-	EventType string  
+	Guests     []Guest
+	EventType  string
+	Report     ImportReport
+	Duplicates []Duplicate
+	Merged     []Duplicate
+	Source     string
+	Worksheet  string
+	Rows       [][]string
 }
 
 
-func (db *Database) ProcessEvent() (*Event, error) {
+// EventTypes are the event types a worksheet can be routed as.
+var EventTypes = []string{"Dinner", "Grocery"}
 
-	sheet := &db.sheet.Sheets[0]
 
-	
-	et, err := determineEventType(sheet)
-	if err != nil {
-		return nil, fmt.Errorf("Please fix spreadsheet title. %v", err)
+// processWorksheet reads the guests from one worksheet. An empty eventType
+// is inferred from the worksheet title.
+func processWorksheet(ws worksheet, eventType string) (*Event, error) {
+
+	et := eventType
+	if et == "" {
+		var err error
+		et, err = determineEventType(ws.title)
+		if err != nil {
+			return nil, fmt.Errorf("Please fix the worksheet title or choose the event type. %v", err)
+		}
+	} else if !isEventType(et) {
+		return nil, fmt.Errorf("unknown event type %q", et)
 	}
 
-	
-	firstRow := &db.sheet.Sheets[0].Rows[0]
-	err = verifyColumnTitles(firstRow)
+	if len(ws.rows) == 0 {
+		return nil, fmt.Errorf("Column title verification failed: sheet %q is empty", ws.title)
+	}
+
+	columns, err := mapColumns(ws.rows[0])
 	if err != nil {
 		return nil, fmt.Errorf("Column title verification failed: %v", err)
 	}
 
 	guests := make([]Guest, 0, 30)
+	var report ImportReport
 
 	
-	for i := 1; i < len(db.sheet.Sheets[0].Rows); i++ {
-		g, ok := processGuest(&db.sheet.Sheets[0].Rows[i])
-		if ok {
-			guests = append(guests, g)
+	for i := 1; i < len(ws.rows); i++ {
+		row := ws.rows[i]
+		if len(nonEmpty(row)) == 0 {
+			continue
 		}
+
+		rowNumber := i + 1
+		g, ok := processGuest(row, columns, rowNumber, &report)
+		if !ok {
+			continue
+		}
+
+		guests = append(guests, g)
 	}
-	return &Event{Guests: guests, EventType: et}, nil
+
+	assignIDs(guests, &report)
+	duplicates := findDuplicates(guests, &report)
+	return &Event{Guests: guests, EventType: et, Report: report, Duplicates: duplicates, Worksheet: ws.title, Rows: ws.rows}, nil
 }
 
 
-func determineEventType(sheet *spreadsheet.Sheet) (string, error) {
-	title := sheet.Properties.Title
+// guestKey identifies a guest by name and address, ignoring case and spacing.
+func guestKey(g Guest) string {
+	return normalizeHeader(g.Name) + "|" + normalizeHeader(g.Address) + "|" + normalizeHeader(g.Unit)
+}
+
 
+func isEventType(et string) bool {
+	for _, t := range EventTypes {
+		if t == et {
+			return true
+		}
+	}
+	return false
+}
+
+
+func determineEventType(title string) (string, error) {
 	switch {
 	case strings.Contains(title, "Dinner"):
 		return "Dinner", nil
@@ -56,21 +97,3 @@ func determineEventType(sheet *spreadsheet.Sheet) (string, error) {
 		return "", fmt.Errorf("title must include either 'Dinner' or 'Grocery'")
 	}
 }
-
-
-
-func verifyColumnTitles(row *[]spreadsheet.Cell) error {
-	correctOrder := []string{"Status", "Name", "Group Size", "Number", "Address"}
-
-	if len(*row) < len(correctOrder) {
-		return fmt.Errorf("row too short: expected at least %d columns, got %d", len(correctOrder), len(*row))
-	}
-
-	for i, expected := range correctOrder {
-		if (*row)[i].Value != expected {
-			return fmt.Errorf("column %d mismatch: expected %q, got %q", i, expected, (*row)[i].Value)
-		}
-	}
-
-	return nil
-}
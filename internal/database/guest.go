@@ -2,8 +2,6 @@ package database
 
 import (
 	"strconv"
-
-	"gopkg.in/Iwark/spreadsheet.v2"
 )
 
 
@@ -22,33 +20,67 @@ const (
 
 type Guest struct {
 	Status      GuestStatus 
+	ID          string
 	Name        string
 	GroupSize   int 
 	PhoneNumber string
 	Address     string
+	Unit        string
+	Notes       string
+	Extra       map[string]string
+	Row         int
 }
 
 
-func processGuest(row *[]spreadsheet.Cell) (Guest, bool) {
-	status := determineGuestStatus((*row)[0].Value)
-	name := (*row)[1].Value
-	count := (*row)[2].Value
-	phone := (*row)[3].Value
-	address := (*row)[4].Value
+func processGuest(row []string, columns columnMap, rowNumber int, report *ImportReport) (Guest, bool) {
+	rawStatus := columns.value(row, columnStatus)
+	status := determineGuestStatus(rawStatus)
+	name := columns.value(row, columnName)
+	count := columns.value(row, columnGroupSize)
+	phone := columns.value(row, columnNumber)
+	address := SplitAddress(columns.value(row, columnAddress))
 	validGuest := true
 
+	// Rows whose status keeps them off the routes are dropped quietly when
+	// incomplete; the coordinator only needs to fix the rows being routed.
+	routed := Guest{Status: status}.routed()
+	skip := func(format string, args ...any) {
+		if routed {
+			report.add(rowNumber, name, IssueSkipped, format, args...)
+		}
+		validGuest = false
+	}
+
 	if count == "" {
 		count = "0"
 	}
 	
 	iCount, err := strconv.Atoi(count)
 	if err != nil {
-		validGuest = false
+		skip("group size %q is not a number", count)
+	} else if iCount < 0 {
+		skip("group size %d is negative", iCount)
 	}
 
 	
-	if name == "" || address == "" {
-		validGuest = false
+	if name == "" {
+		skip("name is missing")
+	}
+	if address.Street == "" {
+		skip("address is missing")
+	}
+
+	if status == Undecided && rawStatus != "" {
+		report.add(rowNumber, name, IssueWarning, "unknown status %q, treated as undecided", rawStatus)
+	}
+
+	if validGuest && routed {
+		if phone == "" {
+			report.add(rowNumber, name, IssueWarning, "phone number is blank")
+		}
+		if status == Confirmed && iCount == 0 {
+			report.add(rowNumber, name, IssueWarning, "confirmed with a group size of 0")
+		}
 	}
 
 	if status == GroceryOnly {
@@ -57,10 +89,15 @@ func processGuest(row *[]spreadsheet.Cell) (Guest, bool) {
 
 	return Guest{
 		Status:      status,
+		ID:          columns.value(row, columnID),
 		Name:        name,
 		GroupSize:   iCount,
 		PhoneNumber: phone,
-		Address:     address,
+		Address:     address.Street,
+		Unit:        address.Unit,
+		Notes:       address.Notes,
+		Extra:       columns.extraValues(row),
+		Row:         rowNumber,
 	}, validGuest
 }
 
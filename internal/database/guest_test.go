@@ -0,0 +1,50 @@
+package database
+
+import "testing"
+
+func TestProcessGuestReportsOnlyRoutedRows(t *testing.T) {
+	columns, err := mapColumns(guestHeader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		row    []string
+		valid  bool
+		issues []string
+	}{
+		{[]string{"Confirmed", "Mina", "2", "613-555-0100", "1 Main St"}, true, nil},
+		{[]string{"Confirmed", "Mina", "two", "613-555-0100", "1 Main St"}, false, []string{`group size "two" is not a number`}},
+		{[]string{"Grocery Only", "", "1", "613-555-0100", "1 Main St"}, false, []string{"name is missing"}},
+		{[]string{"Confirmed", "Mina", "-1", "", ""}, false, []string{"group size -1 is negative", "address is missing"}},
+		{[]string{"Confirmed", "Mina", "2", "", "1 Main St"}, true, []string{"phone number is blank"}},
+
+		// Rows that are not routed are dropped without a word.
+		{[]string{"NO", "Sara", "two", "", "2 Main St"}, false, nil},
+		{[]string{"Pending", "", "-3", "", ""}, false, nil},
+		{[]string{"Not started", "Sara", "1", "", "2 Main St"}, true, nil},
+
+		// A status nobody recognises may be a typo of one that is routed.
+		{[]string{"Confirmd", "Sara", "x", "", "2 Main St"}, false, []string{`unknown status "Confirmd", treated as undecided`}},
+	}
+	for _, tt := range tests {
+		var report ImportReport
+		_, valid := processGuest(tt.row, columns, 2, &report)
+		if valid != tt.valid {
+			t.Errorf("%v: valid = %v, want %v", tt.row, valid, tt.valid)
+		}
+		var got []string
+		for _, issue := range report.Issues {
+			got = append(got, issue.Reason)
+		}
+		if len(got) != len(tt.issues) {
+			t.Errorf("%v: issues = %q, want %q", tt.row, got, tt.issues)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.issues[i] {
+				t.Errorf("%v: issues = %q, want %q", tt.row, got, tt.issues)
+				break
+			}
+		}
+	}
+}
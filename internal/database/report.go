@@ -0,0 +1,86 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+type IssueSeverity int
+
+const (
+	IssueWarning IssueSeverity = iota
+	IssueSkipped
+)
+
+func (s IssueSeverity) String() string {
+	if s == IssueSkipped {
+		return "Skipped"
+	}
+	return "Warning"
+}
+
+// RowIssue is a problem found in one sheet row. Row is the row number as
+// shown in the spreadsheet, counting the header as row 1.
+type RowIssue struct {
+	Row      int
+	Name     string
+	Severity IssueSeverity
+	Reason   string
+}
+
+// ImportReport lists every row that was skipped or looks suspicious so the
+// coordinator can fix the sheet before routing.
+type ImportReport struct {
+	Issues []RowIssue
+}
+
+func (r *ImportReport) add(row int, name string, severity IssueSeverity, format string, args ...any) {
+	r.Issues = append(r.Issues, RowIssue{
+		Row:      row,
+		Name:     name,
+		Severity: severity,
+		Reason:   fmt.Sprintf(format, args...),
+	})
+}
+
+func (r *ImportReport) HasIssues() bool {
+	return len(r.Issues) > 0
+}
+
+func (r *ImportReport) count(severity IssueSeverity) int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *ImportReport) GetSummary() string {
+	if !r.HasIssues() {
+		return ""
+	}
+	return fmt.Sprintf("%d rows were skipped and %d rows have warnings",
+		r.count(IssueSkipped), r.count(IssueWarning))
+}
+
+func (r *ImportReport) GetDetails() string {
+	if !r.HasIssues() {
+		return ""
+	}
+
+	var details strings.Builder
+	for i, issue := range r.Issues {
+		if i > 0 {
+			details.WriteString("\n")
+		}
+		name := issue.Name
+		if name == "" {
+			name = "(no name)"
+		}
+		details.WriteString(fmt.Sprintf("Row %d • %s • %s: %s",
+			issue.Row, issue.Severity, name, issue.Reason))
+	}
+	return details.String()
+}
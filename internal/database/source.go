@@ -0,0 +1,76 @@
+package database
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// GuestSource is anywhere a guest list can be read from: a Google
+// spreadsheet or an exported CSV or Excel file. Each source holds one or
+// more worksheets laid out like the guest sheet.
+type GuestSource interface {
+	Worksheets() []string
+	LatestWorksheet() string
+	ProcessWorksheet(title, eventType string) (*Event, error)
+}
+
+// worksheet is one tab of a source as plain cell text, header row first.
+type worksheet struct {
+	title string
+	rows  [][]string
+}
+
+// workbook is a GuestSource read fully into memory.
+type workbook []worksheet
+
+func (wb workbook) Worksheets() []string {
+	titles := make([]string, len(wb))
+	for i, ws := range wb {
+		titles[i] = ws.title
+	}
+	return titles
+}
+
+func (wb workbook) LatestWorksheet() string {
+	return latestWorksheet(wb.Worksheets())
+}
+
+// ProcessWorksheet reads the guests from the worksheet with the given title.
+// An empty eventType is inferred from the title.
+func (wb workbook) ProcessWorksheet(title, eventType string) (*Event, error) {
+	for _, ws := range wb {
+		if ws.title == title {
+			return processWorksheet(ws, eventType)
+		}
+	}
+	return nil, fmt.Errorf("worksheet %q not found", title)
+}
+
+// RecordedWorksheet returns a source holding a single worksheet, such as
+// the rows saved with a recorded run.
+func RecordedWorksheet(title string, rows [][]string) GuestSource {
+	return workbook{{title: title, rows: rows}}
+}
+
+// IsGuestFile reports whether path names a file OpenFile can read.
+func IsGuestFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv", ".xlsx":
+		return true
+	default:
+		return false
+	}
+}
+
+// OpenFile reads a guest list exported as CSV or Excel, chosen by extension.
+func OpenFile(path string) (GuestSource, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return OpenCSV(path)
+	case ".xlsx":
+		return OpenXLSX(path)
+	default:
+		return nil, fmt.Errorf("unsupported file type %q, expected .csv or .xlsx", filepath.Ext(path))
+	}
+}
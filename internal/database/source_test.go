@@ -0,0 +1,209 @@
+package database
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	xlsxWorkbookXML = `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+ xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+ <sheets>
+  <sheet name="Dinner June 12" sheetId="1" r:id="rId1"/>
+  <sheet name="Notes" sheetId="2" r:id="rId2"/>
+ </sheets>
+</workbook>`
+
+	xlsxRelsXML = `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+ <Relationship Id="rId1" Target="worksheets/sheet1.xml"/>
+ <Relationship Id="rId2" Target="/xl/worksheets/sheet2.xml"/>
+</Relationships>`
+
+	xlsxSharedXML = `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+ <si><t>Status</t></si>
+ <si><t>Name</t></si>
+ <si><r><t>Group </t></r><r><t>Size</t></r></si>
+ <si><t>Address</t></si>
+ <si><t>Confirmed</t></si>
+</sst>`
+
+	// Row 1 is the header, with Number typed inline. Row 2 leaves the
+	// phone cell out, row 3 is missing and row 4 has cells without refs.
+	xlsxSheet1XML = `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+ <sheetData>
+  <row r="1">
+   <c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c>
+   <c r="D1" t="inlineStr"><is><t>Number</t></is></c><c r="E1" t="s"><v>3</v></c>
+   <c r="F1" t="inlineStr"><is><t>Driver Needed</t></is></c>
+  </row>
+  <row r="2">
+   <c r="A2" t="s"><v>4</v></c><c r="B2" t="inlineStr"><is><r><t>Mina </t></r><r><t>Ayad</t></r></is></c>
+   <c r="C2"><v>3</v></c><c r="E2" t="str"><v>1 Main St</v></c><c r="F2" t="b"><v>1</v></c>
+  </row>
+  <row r="4">
+   <c t="s"><v>4</v></c><c t="inlineStr"><is><t>Sara</t></is></c><c><v>1</v></c>
+   <c><v>6135550100</v></c><c t="inlineStr"><is><t>2 Elm St</t></is></c><c t="b"><v>0</v></c>
+  </row>
+  <row r="5">
+   <c r="AA5" t="s"><v>99</v></c>
+  </row>
+ </sheetData>
+</worksheet>`
+
+	xlsxSheet2XML = `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+ <sheetData><row><c t="inlineStr"><is><t>free text</t></is></c></row></sheetData>
+</worksheet>`
+)
+
+// writeXLSX zips parts into a workbook file and returns its path.
+func writeXLSX(t *testing.T, parts map[string]string) string {
+	t.Helper()
+	name := filepath.Join(t.TempDir(), "guests.xlsx")
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	for part, content := range parts {
+		w, err := zw.Create(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+func xlsxParts(sheet1 string) map[string]string {
+	return map[string]string{
+		"xl/workbook.xml":            xlsxWorkbookXML,
+		"xl/_rels/workbook.xml.rels": xlsxRelsXML,
+		"xl/sharedStrings.xml":       xlsxSharedXML,
+		"xl/worksheets/sheet1.xml":   sheet1,
+		"xl/worksheets/sheet2.xml":   xlsxSheet2XML,
+	}
+}
+
+func TestOpenXLSX(t *testing.T) {
+	source, err := OpenFile(writeXLSX(t, xlsxParts(xlsxSheet1XML)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := source.Worksheets(); !reflect.DeepEqual(got, []string{"Dinner June 12", "Notes"}) {
+		t.Errorf("Worksheets = %q", got)
+	}
+
+	want := [][]string{
+		{"Status", "Name", "Group Size", "Number", "Address", "Driver Needed"},
+		{"Confirmed", "Mina Ayad", "3", "", "1 Main St", "TRUE"},
+		nil,
+		{"Confirmed", "Sara", "1", "6135550100", "2 Elm St", "FALSE"},
+		append(make([]string, 26), ""),
+	}
+	book := source.(workbook)
+	if !reflect.DeepEqual(book[0].rows, want) {
+		t.Errorf("rows = %q\nwant %q", book[0].rows, want)
+	}
+	if !reflect.DeepEqual(book[1].rows, [][]string{{"free text"}}) {
+		t.Errorf("Notes rows = %q", book[1].rows)
+	}
+
+	event, err := source.ProcessWorksheet("Dinner June 12", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.EventType != "Dinner" || len(event.Guests) != 2 {
+		t.Fatalf("event = %s with %d guests, want a dinner with 2", event.EventType, len(event.Guests))
+	}
+	if g := event.Guests[0]; g.Name != "Mina Ayad" || g.GroupSize != 3 || g.Row != 2 || g.Extra["Driver Needed"] != "TRUE" {
+		t.Errorf("first guest = %+v", g)
+	}
+}
+
+func TestOpenXLSXBadReference(t *testing.T) {
+	for _, ref := range []string{"1A", "$A$1", "XFE1", "ZZZZ1"} {
+		sheet := strings.Replace(xlsxSheet2XML, "<c t=", `<c r="`+ref+`" t=`, 1)
+		parts := xlsxParts(xlsxSheet1XML)
+		parts["xl/worksheets/sheet2.xml"] = sheet
+		_, err := OpenXLSX(writeXLSX(t, parts))
+		if err == nil || !strings.Contains(err.Error(), `"Notes"`) || !strings.Contains(err.Error(), ref) {
+			t.Errorf("ref %q: err = %v, want the sheet and reference named", ref, err)
+		}
+	}
+}
+
+func TestOpenXLSXMissingPart(t *testing.T) {
+	parts := xlsxParts(xlsxSheet1XML)
+	delete(parts, "xl/worksheets/sheet1.xml")
+	if _, err := OpenXLSX(writeXLSX(t, parts)); err == nil || !strings.Contains(err.Error(), "sheet1.xml") {
+		t.Errorf("err = %v, want the missing sheet named", err)
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	for ref, want := range map[string]int{"A1": 0, "z9": 25, "AA1": 26, "AB12": 27, "XFD1": 16383, "1A": -1, "": -1} {
+		if got := columnIndex(ref); got != want {
+			t.Errorf("columnIndex(%q) = %d, want %d", ref, got, want)
+		}
+	}
+}
+
+func TestOpenCSV(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "Grocery June 14.csv")
+	content := "\ufeffStatus,Name,Group Size,Number,Address\n" +
+		"Grocery Only,\"Ayad, Mina\",2,613-555-0100,\"304-123 Main St, buzz 12\"\n" +
+		"Confirmed,Sara\n" +
+		"Confirmed,Jo \"JJ\" Lee,1,,2 Elm St,extra\n"
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := OpenFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"Status", "Name", "Group Size", "Number", "Address"},
+		{"Grocery Only", "Ayad, Mina", "2", "613-555-0100", "304-123 Main St, buzz 12"},
+		{"Confirmed", "Sara"},
+		{"Confirmed", `Jo "JJ" Lee`, "1", "", "2 Elm St", "extra"},
+	}
+	book := source.(workbook)
+	if len(book) != 1 || book[0].title != "Grocery June 14" || !reflect.DeepEqual(book[0].rows, want) {
+		t.Fatalf("workbook = %q", book)
+	}
+
+	event, err := source.ProcessWorksheet("Grocery June 14", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.EventType != "Grocery" || len(event.Guests) != 2 {
+		t.Fatalf("event = %s with %d guests, want a grocery run with 2", event.EventType, len(event.Guests))
+	}
+	if g := event.Guests[0]; g.Address != "123 Main St" || g.Unit != "304" || g.Notes != "Buzzer 12" {
+		t.Errorf("first guest = %+v", g)
+	}
+}
+
+func TestOpenCSVMissingFile(t *testing.T) {
+	if _, err := OpenCSV(filepath.Join(t.TempDir(), "none.csv")); err == nil {
+		t.Error("OpenCSV read a file that does not exist")
+	}
+}
@@ -15,7 +15,43 @@ import (
 
 
 type Database struct {
-	sheet spreadsheet.Spreadsheet
+	sheet   spreadsheet.Spreadsheet
+	service *spreadsheet.Service
+}
+
+
+func (db *Database) workbook() workbook {
+	book := make(workbook, len(db.sheet.Sheets))
+	for i, s := range db.sheet.Sheets {
+		rows := make([][]string, len(s.Rows))
+		for r := range s.Rows {
+			rows[r] = rowValues(s.Rows[r])
+		}
+		book[i] = worksheet{title: s.Properties.Title, rows: rows}
+	}
+	return book
+}
+
+// Worksheets returns the titles of the spreadsheet's tabs in sheet order.
+func (db *Database) Worksheets() []string {
+	return db.workbook().Worksheets()
+}
+
+// LatestWorksheet returns the title of the most recent tab: the one whose
+// title holds the latest date, or the last tab when no title has a date.
+func (db *Database) LatestWorksheet() string {
+	return db.workbook().LatestWorksheet()
+}
+
+// ProcessWorksheet reads the guests from the tab with the given title. An
+// empty eventType is inferred from the tab title.
+func (db *Database) ProcessWorksheet(title, eventType string) (*Event, error) {
+	return db.workbook().ProcessWorksheet(title, eventType)
+}
+
+
+func (db *Database) ProcessEvent() (*Event, error) {
+	return db.ProcessWorksheet(db.LatestWorksheet(), "")
 }
 
 
@@ -30,7 +66,7 @@ func NewSheetClient(spreadsheetID string) (*Database, error) {
 			sheet, err := service.FetchSpreadsheet(spreadsheetID)
 			if err != nil {
 			} else {
-				return &Database{sheet: sheet}, nil
+				return &Database{sheet: sheet, service: service}, nil
 			}
 		} else {
 			fmt.Printf("Warning: embedded credentials are invalid: %v\n", err)
@@ -40,7 +76,7 @@ func NewSheetClient(spreadsheetID string) (*Database, error) {
 	
 	projectRoot, err := filepath.Abs(filepath.Join(".", ".."))
 	if err != nil {
-		return nil, fmt.Errorf("failed to resolve project root:", err)
+		return nil, fmt.Errorf("failed to resolve project root: %w", err)
 	}
 	credentialsPath := filepath.Join(projectRoot, "client_secret.json")
 
@@ -62,7 +98,7 @@ func NewSheetClient(spreadsheetID string) (*Database, error) {
 		return nil, fmt.Errorf("Failed to fetch spreadsheet: %v", err)
 	}
 
-	return &Database{sheet: sheet}, nil
+	return &Database{sheet: sheet, service: service}, nil
 }
 
 
@@ -0,0 +1,117 @@
+package database
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	isoDatePattern     = regexp.MustCompile(`\b(\d{4})[-/.](\d{1,2})[-/.](\d{1,2})\b`)
+	numericDatePattern = regexp.MustCompile(`\b(\d{1,2})[/.](\d{1,2})(?:[/.](\d{2,4}))?\b`)
+	monthDayPattern    = regexp.MustCompile(`(?i)\b(jan|feb|mar|apr|may|jun|jul|aug|sep|sept|oct|nov|dec)[a-z]*\.?\s+(\d{1,2})(?:st|nd|rd|th)?(?:,?\s+(\d{4}))?\b`)
+	dayMonthPattern    = regexp.MustCompile(`(?i)\b(\d{1,2})(?:st|nd|rd|th)?\s+(jan|feb|mar|apr|may|jun|jul|aug|sep|sept|oct|nov|dec)[a-z]*\.?(?:,?\s+(\d{4}))?\b`)
+)
+
+var monthNumbers = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+	"may": time.May, "jun": time.June, "jul": time.July, "aug": time.August,
+	"sep": time.September, "sept": time.September, "oct": time.October,
+	"nov": time.November, "dec": time.December,
+}
+
+// latestWorksheet returns the most recent of titles: the one holding the
+// latest date, or the last one when no title has a date.
+func latestWorksheet(titles []string) string {
+	if len(titles) == 0 {
+		return ""
+	}
+
+	now := time.Now()
+	latest := titles[len(titles)-1]
+	var latestDate time.Time
+	for _, title := range titles {
+		date, ok := worksheetDate(title, now)
+		if ok && !date.Before(latestDate) {
+			latest, latestDate = title, date
+		}
+	}
+	return latest
+}
+
+// WorksheetDate returns the event date written in a worksheet title.
+func WorksheetDate(title string) (time.Time, bool) {
+	return worksheetDate(title, time.Now())
+}
+
+// worksheetDate reads a date from a tab title such as "Dinner June 12",
+// "Grocery 2025-06-12" or "12/06". Numeric dates are read day first, as
+// written in Canada, so "12/06" is June 12; they are read month first only
+// when that is the sole valid reading, as in "06/25". Titles without a year
+// are placed in the year that brings them closest to now, so a "Jan 4" tab
+// routed in December falls in the coming year.
+func worksheetDate(title string, now time.Time) (time.Time, bool) {
+	if m := isoDatePattern.FindStringSubmatch(title); m != nil {
+		return makeDate(m[1], atoi(m[2]), m[3], now)
+	}
+	if m := monthDayPattern.FindStringSubmatch(title); m != nil {
+		return makeDate(m[3], int(monthNumbers[strings.ToLower(m[1])]), m[2], now)
+	}
+	if m := dayMonthPattern.FindStringSubmatch(title); m != nil {
+		return makeDate(m[3], int(monthNumbers[strings.ToLower(m[2])]), m[1], now)
+	}
+	if m := numericDatePattern.FindStringSubmatch(title); m != nil {
+		if date, ok := makeDate(m[3], atoi(m[2]), m[1], now); ok {
+			return date, true
+		}
+		return makeDate(m[3], atoi(m[1]), m[2], now)
+	}
+	return time.Time{}, false
+}
+
+// makeDate builds the date, rejecting days the month does not have. Without
+// a year it picks the year nearest to now in which the date exists.
+func makeDate(year string, month int, day string, now time.Time) (time.Time, bool) {
+	d := atoi(day)
+	if year != "" {
+		y := atoi(year)
+		if y < 100 {
+			y += 2000
+		}
+		return validDate(y, month, d)
+	}
+
+	var best time.Time
+	found := false
+	for y := now.Year() - 1; y <= now.Year()+1; y++ {
+		date, ok := validDate(y, month, d)
+		if ok && (!found || absDuration(date.Sub(now)) < absDuration(best.Sub(now))) {
+			best, found = date, true
+		}
+	}
+	return best, found
+}
+
+func validDate(year, month, day int) (time.Time, bool) {
+	if month < 1 || month > 12 || day < 1 {
+		return time.Time{}, false
+	}
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local)
+	if date.Day() != day {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorksheetDate(t *testing.T) {
+	now := time.Date(2025, time.June, 1, 15, 0, 0, 0, time.Local)
+	december := time.Date(2025, time.December, 20, 15, 0, 0, 0, time.Local)
+	tests := []struct {
+		title string
+		now   time.Time
+		want  string
+	}{
+		{"Dinner June 12", now, "2025-06-12"},
+		{"Grocery 2025-06-12", now, "2025-06-12"},
+		{"12 June 2024", now, "2024-06-12"},
+		{"12/06", now, "2025-06-12"},
+		{"06/25", now, "2025-06-25"},
+		{"12/06/24", now, "2024-06-12"},
+		{"Jan 4", december, "2026-01-04"},
+		{"Dec 28", time.Date(2026, time.January, 3, 0, 0, 0, 0, time.Local), "2025-12-28"},
+		{"Feb 29", now, "2024-02-29"},
+		{"02/31", now, ""},
+		{"31/02", now, ""},
+		{"2025-02-30", now, ""},
+		{"April 31", now, ""},
+		{"Sheet1", now, ""},
+	}
+	for _, tt := range tests {
+		date, ok := worksheetDate(tt.title, tt.now)
+		got := ""
+		if ok {
+			got = date.Format("2006-01-02")
+		}
+		if got != tt.want {
+			t.Errorf("worksheetDate(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
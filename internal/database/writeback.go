@@ -0,0 +1,258 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/Iwark/spreadsheet.v2"
+)
+
+const (
+	columnDriver = "Driver"
+	columnStop   = "Stop #"
+)
+
+// outputColumns are written by the app and ignored when reading guests back.
+// Only the app's own Driver header is claimed, so a coordinator's Vehicle
+// column is left alone.
+var outputColumns = map[string][]string{
+	columnDriver: {"Driver"},
+	columnStop:   {"Stop #", "Stop", "Stop Number"},
+}
+
+// StopAssignment is one guest's place in a vehicle's route. ID is the
+// guest's ID from import and Row their row in the worksheet it was imported
+// from, or 0 when unknown.
+type StopAssignment struct {
+	ID          string
+	Row         int
+	Stop        int
+	Name        string
+	Address     string
+	Unit        string
+	PhoneNumber string
+	GroupSize   int
+	Notes       string
+}
+
+// VehicleAssignment is the ordered list of stops for one driver, with the
+// navigation links for the route.
+type VehicleAssignment struct {
+	Driver     string
+	Stops      []StopAssignment
+	Directions []string
+}
+
+// WriteAssignments fills a Driver and a Stop # column on the guest worksheet,
+// adding the columns after the last header when they are missing. Values
+// left from an earlier run are cleared. A group split between vehicles has
+// every driver and stop listed on its row. Guests whose row cannot be found
+// are reported in the returned error after the rest have been written.
+func (db *Database) WriteAssignments(title string, vehicles []VehicleAssignment) error {
+	sheet, err := db.sheet.SheetByTitle(title)
+	if err != nil {
+		return fmt.Errorf("worksheet %q not found in spreadsheet", title)
+	}
+	if len(sheet.Rows) == 0 {
+		return fmt.Errorf("worksheet %q is empty", title)
+	}
+
+	header := rowValues(sheet.Rows[0])
+	columns, err := mapColumns(header)
+	if err != nil {
+		return fmt.Errorf("Column title verification failed: %v", err)
+	}
+
+	driverCol := outputColumn(sheet, header, columnDriver)
+	if driverCol >= len(header) {
+		header = append(header, columnDriver)
+	}
+	stopCol := outputColumn(sheet, header, columnStop)
+
+	for r := 1; r < len(sheet.Rows); r++ {
+		row := rowValues(sheet.Rows[r])
+		for _, col := range []int{driverCol, stopCol} {
+			if col < len(row) && row[col] != "" {
+				sheet.Update(r, col, "")
+			}
+		}
+	}
+
+	cells, missing := rowAssignments(sheet, columns, vehicles)
+	for r, cell := range cells {
+		sheet.Update(r, driverCol, cell.driver)
+		sheet.Update(r, stopCol, cell.stop)
+	}
+
+	if err := db.service.SyncSheet(sheet); err != nil {
+		return fmt.Errorf("could not update worksheet %q: %w", title, err)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("could not find the sheet rows for: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+type assignmentCell struct {
+	driver string
+	stop   string
+}
+
+// rowAssignments returns the Driver and Stop # values for each guest row,
+// and the names of guests whose row cannot be found. A row carried by
+// several vehicles reads "Driver 1 (4), Driver 2 (2)" with its stops in the
+// same order.
+func rowAssignments(sheet *spreadsheet.Sheet, columns columnMap, vehicles []VehicleAssignment) (map[int]assignmentCell, []string) {
+	type part struct {
+		driver string
+		stop   StopAssignment
+	}
+	parts := make(map[int][]part)
+	missing := make([]string, 0)
+	for _, v := range vehicles {
+		for _, stop := range v.Stops {
+			r := findGuestRow(sheet, columns, stop)
+			if r < 0 {
+				missing = append(missing, stop.Name)
+				continue
+			}
+			parts[r] = append(parts[r], part{v.Driver, stop})
+		}
+	}
+
+	cells := make(map[int]assignmentCell, len(parts))
+	for r, ps := range parts {
+		if len(ps) == 1 {
+			cells[r] = assignmentCell{ps[0].driver, strconv.Itoa(ps[0].stop.Stop)}
+			continue
+		}
+		drivers := make([]string, len(ps))
+		stops := make([]string, len(ps))
+		for i, p := range ps {
+			drivers[i] = fmt.Sprintf("%s (%d)", p.driver, p.stop.GroupSize)
+			stops[i] = strconv.Itoa(p.stop.Stop)
+		}
+		cells[r] = assignmentCell{strings.Join(drivers, ", "), strings.Join(stops, ", ")}
+	}
+	return cells, missing
+}
+
+// outputColumn returns the index of column in header, writing its title
+// after the last header cell when the column is missing.
+func outputColumn(sheet *spreadsheet.Sheet, header []string, column string) int {
+	for i, title := range header {
+		for _, alias := range outputColumns[column] {
+			if normalizeHeader(title) == normalizeHeader(alias) {
+				return i
+			}
+		}
+	}
+	sheet.Update(0, len(header), column)
+	return len(header)
+}
+
+// findGuestRow returns the zero-based sheet row of a guest. The row recorded
+// at import is used when it still holds the guest's ID; otherwise the sheet
+// is searched for the one row with that ID, since rows may have moved since
+// the import. Guests without an ID, as in sessions saved by earlier
+// versions, are only found at their recorded row, and only while it still
+// holds their name.
+func findGuestRow(sheet *spreadsheet.Sheet, columns columnMap, stop StopAssignment) int {
+	if r := stop.Row - 1; r > 0 && r < len(sheet.Rows) {
+		row := rowValues(sheet.Rows[r])
+		if stop.ID == "" {
+			if normalizeHeader(columns.value(row, columnName)) == normalizeHeader(stop.Name) {
+				return r
+			}
+			return -1
+		}
+		id := rowID(row, columns)
+		if id == stop.ID || strings.HasPrefix(stop.ID, id+"-") {
+			return r
+		}
+	}
+	if stop.ID == "" {
+		return -1
+	}
+
+	found := -1
+	for r := 1; r < len(sheet.Rows); r++ {
+		if rowID(rowValues(sheet.Rows[r]), columns) == stop.ID {
+			if found >= 0 {
+				return -1
+			}
+			found = r
+		}
+	}
+	return found
+}
+
+// rowID is the ID a guest on row was given at import before any suffix
+// for a repeated ID: the sheet's ID, or the hash of the name and address.
+func rowID(row []string, columns columnMap) string {
+	if id := columns.value(row, columnID); id != "" {
+		return id
+	}
+	address := SplitAddress(columns.value(row, columnAddress))
+	return hashID(Guest{Name: columns.value(row, columnName), Address: address.Street, Unit: address.Unit})
+}
+
+// WriteRoutesSheet adds a "Routes <date>" tab with one block per vehicle
+// and returns its title. An existing tab is never overwritten; a number is
+// appended to the title instead.
+func (db *Database) WriteRoutesSheet(date time.Time, vehicles []VehicleAssignment) (string, error) {
+	base := fmt.Sprintf("Routes %s", date.Format("2006-01-02"))
+	title := base
+	for n := 2; db.hasWorksheet(title); n++ {
+		title = fmt.Sprintf("%s (%d)", base, n)
+	}
+
+	err := db.service.AddSheet(&db.sheet, spreadsheet.SheetProperties{Title: title})
+	if err != nil {
+		return "", fmt.Errorf("could not add worksheet %q: %w", title, err)
+	}
+	sheet, err := db.sheet.SheetByTitle(title)
+	if err != nil {
+		return "", fmt.Errorf("worksheet %q not found after adding it", title)
+	}
+
+	row := 0
+	writeRow := func(values ...string) {
+		for col, v := range values {
+			sheet.Update(row, col, v)
+		}
+		row++
+	}
+
+	for _, v := range vehicles {
+		if len(v.Stops) == 0 {
+			continue
+		}
+		writeRow(v.Driver)
+		for _, link := range v.Directions {
+			writeRow("Directions", link)
+		}
+		writeRow("Stop #", "Name", "Address", "Unit", "Phone", "Group Size", "Notes")
+		for _, s := range v.Stops {
+			writeRow(strconv.Itoa(s.Stop), s.Name, s.Address, s.Unit, s.PhoneNumber,
+				strconv.Itoa(s.GroupSize), s.Notes)
+		}
+		row++
+	}
+
+	if err := db.service.SyncSheet(sheet); err != nil {
+		return "", fmt.Errorf("could not write worksheet %q: %w", title, err)
+	}
+	return title, nil
+}
+
+func (db *Database) hasWorksheet(title string) bool {
+	for _, t := range db.Worksheets() {
+		if t == title {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"testing"
+
+	"gopkg.in/Iwark/spreadsheet.v2"
+)
+
+func testSheet(rows ...[]string) *spreadsheet.Sheet {
+	sheet := &spreadsheet.Sheet{}
+	for r, values := range rows {
+		cells := make([]spreadsheet.Cell, len(values))
+		for c, v := range values {
+			cells[c] = spreadsheet.Cell{Row: uint(r), Column: uint(c), Value: v}
+		}
+		sheet.Rows = append(sheet.Rows, cells)
+	}
+	return sheet
+}
+
+var guestHeader = []string{"Status", "Name", "Group Size", "Number", "Address"}
+
+func guestRow(name, address string) []string {
+	return []string{"Confirmed", name, "2", "613-555-0100", address}
+}
+
+func TestOutputColumnLeavesVehicleColumnAlone(t *testing.T) {
+	header := append(guestHeader, "Vehicle")
+	sheet := testSheet(header)
+	if col := outputColumn(sheet, header, columnDriver); col != len(header) {
+		t.Errorf("Driver column = %d, want a new column at %d", col, len(header))
+	}
+
+	header = append(guestHeader, "Driver", "Vehicle")
+	if col := outputColumn(testSheet(header), header, columnDriver); col != len(guestHeader) {
+		t.Errorf("Driver column = %d, want the existing one at %d", col, len(guestHeader))
+	}
+}
+
+func TestFindGuestRow(t *testing.T) {
+	sheet := testSheet(guestHeader,
+		guestRow("Ann Lee", "1 Main St"),
+		guestRow("Bob Ray", "2 Main St"),
+		guestRow("Bob Ray", "9 Elm St"),
+	)
+	columns, err := mapColumns(guestHeader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob := hashID(Guest{Name: "Bob Ray", Address: "9 Elm St"})
+
+	tests := []struct {
+		name string
+		stop StopAssignment
+		want int
+	}{
+		{"recorded row", StopAssignment{ID: bob, Row: 4, Name: "Bob Ray"}, 3},
+		{"row moved", StopAssignment{ID: bob, Row: 2, Name: "Bob Ray"}, 3},
+		{"same name elsewhere", StopAssignment{ID: "g-unknown", Row: 3, Name: "Bob Ray"}, -1},
+		{"suffixed ID at its row", StopAssignment{ID: bob + "-2", Row: 4, Name: "Bob Ray"}, 3},
+		{"no ID at recorded row", StopAssignment{Row: 2, Name: "Ann Lee"}, 1},
+		{"no ID and row moved", StopAssignment{Row: 3, Name: "Ann Lee"}, -1},
+	}
+	for _, tt := range tests {
+		if got := findGuestRow(sheet, columns, tt.stop); got != tt.want {
+			t.Errorf("%s: findGuestRow = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFindGuestRowByIDColumn(t *testing.T) {
+	header := append(guestHeader, "Guest ID")
+	sheet := testSheet(header,
+		append(guestRow("Ann Lee", "1 Main St"), "A7"),
+		append(guestRow("Ann Lee", "1 Main St"), "A8"),
+	)
+	columns, err := mapColumns(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := findGuestRow(sheet, columns, StopAssignment{ID: "A8", Row: 2, Name: "Ann Lee"}); got != 2 {
+		t.Errorf("findGuestRow = %d, want 2", got)
+	}
+}
+
+func TestRowAssignmentsSplitGroup(t *testing.T) {
+	sheet := testSheet(guestHeader,
+		guestRow("Ann Lee", "1 Main St"),
+		guestRow("Bob Ray", "2 Main St"),
+	)
+	columns, err := mapColumns(guestHeader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ann := hashID(Guest{Name: "Ann Lee", Address: "1 Main St"})
+	bob := hashID(Guest{Name: "Bob Ray", Address: "2 Main St"})
+	vehicles := []VehicleAssignment{
+		{Driver: "Mina", Stops: []StopAssignment{{ID: ann, Row: 2, Stop: 1, Name: "Ann Lee", GroupSize: 4}}},
+		{Driver: "Sara", Stops: []StopAssignment{
+			{ID: bob, Row: 3, Stop: 1, Name: "Bob Ray", GroupSize: 1},
+			{ID: ann, Row: 2, Stop: 2, Name: "Ann Lee", GroupSize: 2},
+			{ID: "g-gone", Row: 9, Stop: 3, Name: "Gone Guest", GroupSize: 1},
+		}},
+	}
+
+	cells, missing := rowAssignments(sheet, columns, vehicles)
+	if want := (assignmentCell{"Mina (4), Sara (2)", "1, 2"}); cells[1] != want {
+		t.Errorf("split row = %+v, want %+v", cells[1], want)
+	}
+	if want := (assignmentCell{"Sara", "1"}); cells[2] != want {
+		t.Errorf("row = %+v, want %+v", cells[2], want)
+	}
+	if len(missing) != 1 || missing[0] != "Gone Guest" {
+		t.Errorf("missing = %v, want [Gone Guest]", missing)
+	}
+}
@@ -0,0 +1,196 @@
+package database
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var sb strings.Builder
+	for _, r := range t.Runs {
+		sb.WriteString(r.T)
+	}
+	return sb.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// OpenXLSX reads a guest list exported from Excel. Every sheet in the
+// workbook becomes a worksheet; only cell text is read, not formatting.
+func OpenXLSX(filename string) (GuestSource, error) {
+	zr, err := zip.OpenReader(filename)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open %s as an Excel workbook: %w", filename, err)
+	}
+	defer zr.Close()
+
+	files := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	var wb xlsxWorkbook
+	if err := readXLSXPart(files, "xl/workbook.xml", &wb); err != nil {
+		return nil, err
+	}
+
+	var rels xlsxRelationships
+	if err := readXLSXPart(files, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, err
+	}
+	targets := make(map[string]string)
+	for _, r := range rels.Relationships {
+		target := strings.TrimPrefix(r.Target, "/")
+		if !strings.HasPrefix(target, "xl/") {
+			target = path.Join("xl", target)
+		}
+		targets[r.ID] = target
+	}
+
+	var shared xlsxSharedStrings
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := readXLSXPart(files, "xl/sharedStrings.xml", &shared); err != nil {
+			return nil, err
+		}
+	}
+
+	book := make(workbook, 0, len(wb.Sheets))
+	for _, s := range wb.Sheets {
+		var sheet xlsxSheet
+		if err := readXLSXPart(files, targets[s.RID], &sheet); err != nil {
+			return nil, err
+		}
+		rows, err := xlsxRows(sheet, shared)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read sheet %q: %w", s.Name, err)
+		}
+		book = append(book, worksheet{title: s.Name, rows: rows})
+	}
+	return book, nil
+}
+
+func readXLSXPart(files map[string]*zip.File, name string, v any) error {
+	f, ok := files[name]
+	if !ok {
+		return fmt.Errorf("workbook is missing %s", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("cannot read %s: %w", name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return fmt.Errorf("cannot read %s: %w", name, err)
+	}
+	if err := xml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("cannot parse %s: %w", name, err)
+	}
+	return nil
+}
+
+// maxXLSXColumns is the widest sheet Excel allows, column XFD.
+const maxXLSXColumns = 16384
+
+// xlsxRows lays the sparse sheet cells out as a dense grid so that row and
+// column positions match what the coordinator sees in Excel. A cell
+// reference that names no column Excel allows is an error.
+func xlsxRows(sheet xlsxSheet, shared xlsxSharedStrings) ([][]string, error) {
+	rows := make([][]string, 0, len(sheet.Rows))
+	for _, r := range sheet.Rows {
+		rowIndex := len(rows)
+		if r.R > 0 {
+			rowIndex = r.R - 1
+		}
+		for len(rows) <= rowIndex {
+			rows = append(rows, nil)
+		}
+
+		row := make([]string, 0, len(r.Cells))
+		for _, c := range r.Cells {
+			col := len(row)
+			if c.Ref != "" {
+				col = columnIndex(c.Ref)
+			}
+			if col < 0 || col >= maxXLSXColumns {
+				return nil, fmt.Errorf("cell reference %q is not valid", c.Ref)
+			}
+			for len(row) <= col {
+				row = append(row, "")
+			}
+
+			switch c.Type {
+			case "s":
+				i, err := strconv.Atoi(c.Value)
+				if err == nil && i >= 0 && i < len(shared.Items) {
+					row[col] = shared.Items[i].String()
+				}
+			case "inlineStr":
+				row[col] = c.Inline.String()
+			case "b":
+				row[col] = map[string]string{"1": "TRUE", "0": "FALSE"}[c.Value]
+			default:
+				row[col] = c.Value
+			}
+		}
+		rows[rowIndex] = row
+	}
+	return rows, nil
+}
+
+// columnIndex converts the letters of a cell reference such as "AB12" to a
+// zero-based column index, or -1 when the reference has no letters.
+func columnIndex(ref string) int {
+	col := 0
+	for _, ch := range strings.ToUpper(ref) {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		col = col*26 + int(ch-'A'+1)
+	}
+	return col - 1
+}
@@ -0,0 +1,306 @@
+package export
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+	"github.com/andrew-tawfik/outreach-routing/internal/palette"
+)
+
+// location is one stop of an itinerary with every guest living there.
+type location struct {
+	Number      int
+	Names       []string
+	Address     string
+	People      int
+	Coordinates coordinates.GuestCoordinates
+}
+
+func (l location) name() string {
+	return strings.Join(l.Names, "; ")
+}
+
+func (it Itinerary) locations() []location {
+	locations := make([]location, 0, len(it.Stops))
+	for _, s := range it.Stops {
+		if n := len(locations); n > 0 && locations[n-1].Number == s.Number {
+			locations[n-1].Names = append(locations[n-1].Names, s.Name)
+			locations[n-1].People += s.GroupSize
+			continue
+		}
+		locations = append(locations, location{
+			Number:      s.Number,
+			Names:       []string{s.Name},
+			Address:     s.Address,
+			People:      s.GroupSize,
+			Coordinates: s.Coordinates,
+		})
+	}
+	return locations
+}
+
+func depotOf(itineraries []Itinerary) (coordinates.GuestCoordinates, bool) {
+	if len(itineraries) == 0 {
+		return coordinates.GuestCoordinates{}, false
+	}
+	return itineraries[0].Depot, true
+}
+
+type gpxDoc struct {
+	XMLName xml.Name   `xml:"gpx"`
+	Version string     `xml:"version,attr"`
+	Creator string     `xml:"creator,attr"`
+	Xmlns   string     `xml:"xmlns,attr"`
+	OsmAnd  string     `xml:"xmlns:osmand,attr"`
+	Name    string     `xml:"metadata>name,omitempty"`
+	Points  []gpxPoint `xml:"wpt"`
+	Routes  []gpxRoute `xml:"rte"`
+}
+
+type gpxPoint struct {
+	Lat   float64 `xml:"lat,attr"`
+	Lon   float64 `xml:"lon,attr"`
+	Name  string  `xml:"name"`
+	Desc  string  `xml:"desc,omitempty"`
+	Type  string  `xml:"type,omitempty"`
+	Color string  `xml:"extensions>osmand:color,omitempty"`
+}
+
+type gpxRoute struct {
+	Name   string     `xml:"name"`
+	Number int        `xml:"number"`
+	Color  string     `xml:"extensions>osmand:color,omitempty"`
+	Points []gpxPoint `xml:"rtept"`
+}
+
+// WriteGPX writes the depot and every stop as waypoints and each vehicle as
+// a route through its stops. Colours use the OsmAnd extension so they match
+// the map legend in OsmAnd.
+func WriteGPX(w io.Writer, itineraries []Itinerary, title string) error {
+	doc := gpxDoc{
+		Version: "1.1",
+		Creator: "Outreach Routing",
+		Xmlns:   "http://www.topografix.com/GPX/1/1",
+		OsmAnd:  "https://osmand.net",
+		Name:    title,
+	}
+
+	depot, ok := depotOf(itineraries)
+	depotPoint := gpxPoint{Lat: depot.Lat, Lon: depot.Long, Name: "Depot", Type: "Depot", Color: palette.Hex(palette.Depot)}
+	if ok {
+		doc.Points = append(doc.Points, depotPoint)
+	}
+
+	for _, it := range itineraries {
+		color := palette.Hex(palette.Vehicle(it.Vehicle))
+		route := gpxRoute{Name: it.Driver, Number: it.Vehicle + 1, Color: color}
+		route.Points = append(route.Points, depotPoint)
+
+		for _, l := range it.locations() {
+			p := gpxPoint{
+				Lat:   l.Coordinates.Lat,
+				Lon:   l.Coordinates.Long,
+				Name:  fmt.Sprintf("%s stop %d: %s", it.Driver, l.Number, l.name()),
+				Desc:  l.Address,
+				Type:  it.Driver,
+				Color: color,
+			}
+			doc.Points = append(doc.Points, p)
+			route.Points = append(route.Points, p)
+		}
+		doc.Routes = append(doc.Routes, route)
+	}
+
+	return writeXML(w, doc)
+}
+
+type kmlDoc struct {
+	XMLName  xml.Name `xml:"kml"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Document struct {
+		Name       string         `xml:"name,omitempty"`
+		Styles     []kmlStyle     `xml:"Style"`
+		Placemarks []kmlPlacemark `xml:"Placemark"`
+		Folders    []kmlFolder    `xml:"Folder"`
+	} `xml:"Document"`
+}
+
+type kmlStyle struct {
+	ID        string  `xml:"id,attr"`
+	IconColor string  `xml:"IconStyle>color"`
+	LineColor string  `xml:"LineStyle>color"`
+	LineWidth float64 `xml:"LineStyle>width"`
+}
+
+type kmlFolder struct {
+	Name       string         `xml:"name"`
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlPlacemark struct {
+	Name        string       `xml:"name"`
+	Description string       `xml:"description,omitempty"`
+	Style       string       `xml:"styleUrl"`
+	Point       *kmlGeometry `xml:"Point,omitempty"`
+	LineString  *kmlGeometry `xml:"LineString,omitempty"`
+}
+
+type kmlGeometry struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+// kmlColor converts a palette colour to KML's aabbggrr notation.
+func kmlColor(name string) string {
+	c := palette.Colors[name]
+	return fmt.Sprintf("ff%02x%02x%02x", c.B, c.G, c.R)
+}
+
+func kmlCoordinate(c coordinates.GuestCoordinates) string {
+	return fmt.Sprintf("%.6f,%.6f,0", c.Long, c.Lat)
+}
+
+// WriteKML writes one folder per vehicle holding a placemark per stop and
+// the route as a line, styled in the vehicle's map colour.
+func WriteKML(w io.Writer, itineraries []Itinerary, title string) error {
+	doc := kmlDoc{Xmlns: "http://www.opengis.net/kml/2.2"}
+	doc.Document.Name = title
+
+	doc.Document.Styles = append(doc.Document.Styles, kmlStyle{
+		ID: "depot", IconColor: kmlColor(palette.Depot), LineColor: kmlColor(palette.Depot), LineWidth: 3,
+	})
+	depot, ok := depotOf(itineraries)
+	if ok {
+		doc.Document.Placemarks = append(doc.Document.Placemarks, kmlPlacemark{
+			Name: "Depot", Style: "#depot", Point: &kmlGeometry{kmlCoordinate(depot)},
+		})
+	}
+
+	for _, it := range itineraries {
+		styleID := fmt.Sprintf("vehicle-%d", it.Vehicle+1)
+		color := kmlColor(palette.Vehicle(it.Vehicle))
+		doc.Document.Styles = append(doc.Document.Styles, kmlStyle{
+			ID: styleID, IconColor: color, LineColor: color, LineWidth: 3,
+		})
+
+		folder := kmlFolder{Name: it.Driver}
+		line := []string{kmlCoordinate(it.Depot)}
+		for _, l := range it.locations() {
+			folder.Placemarks = append(folder.Placemarks, kmlPlacemark{
+				Name:        fmt.Sprintf("%d. %s", l.Number, l.name()),
+				Description: l.Address,
+				Style:       "#" + styleID,
+				Point:       &kmlGeometry{kmlCoordinate(l.Coordinates)},
+			})
+			line = append(line, kmlCoordinate(l.Coordinates))
+		}
+		folder.Placemarks = append(folder.Placemarks, kmlPlacemark{
+			Name:       it.Driver + " route",
+			Style:      "#" + styleID,
+			LineString: &kmlGeometry{strings.Join(line, " ")},
+		})
+		doc.Document.Folders = append(doc.Document.Folders, folder)
+	}
+
+	return writeXML(w, doc)
+}
+
+func writeXML(w io.Writer, doc any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type geoJSONCollection struct {
+	Type     string           `json:"type"`
+	Name     string           `json:"name,omitempty"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string          `json:"type"`
+	Geometry   geoJSONGeometry `json:"geometry"`
+	Properties map[string]any  `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"`
+}
+
+func geoJSONPoint(c coordinates.GuestCoordinates) geoJSONGeometry {
+	return geoJSONGeometry{Type: "Point", Coordinates: []float64{c.Long, c.Lat}}
+}
+
+// WriteGeoJSON writes a FeatureCollection with a point per stop and a line
+// per vehicle. Every feature carries the vehicle, driver and colour so weeks
+// can be overlaid and styled in QGIS; the marker-color and stroke properties
+// follow the simplestyle convention used by most web viewers.
+func WriteGeoJSON(w io.Writer, itineraries []Itinerary, title string) error {
+	collection := geoJSONCollection{Type: "FeatureCollection", Name: title, Features: []geoJSONFeature{}}
+
+	depot, ok := depotOf(itineraries)
+	if ok {
+		collection.Features = append(collection.Features, geoJSONFeature{
+			Type:     "Feature",
+			Geometry: geoJSONPoint(depot),
+			Properties: map[string]any{
+				"kind":         "depot",
+				"name":         "Depot",
+				"color":        palette.Hex(palette.Depot),
+				"marker-color": palette.Hex(palette.Depot),
+			},
+		})
+	}
+
+	for _, it := range itineraries {
+		color := palette.Hex(palette.Vehicle(it.Vehicle))
+		line := [][]float64{{it.Depot.Long, it.Depot.Lat}}
+
+		for _, l := range it.locations() {
+			collection.Features = append(collection.Features, geoJSONFeature{
+				Type:     "Feature",
+				Geometry: geoJSONPoint(l.Coordinates),
+				Properties: map[string]any{
+					"kind":         "stop",
+					"vehicle":      it.Vehicle + 1,
+					"driver":       it.Driver,
+					"stop":         l.Number,
+					"name":         l.name(),
+					"address":      l.Address,
+					"people":       l.People,
+					"color":        color,
+					"marker-color": color,
+				},
+			})
+			line = append(line, []float64{l.Coordinates.Long, l.Coordinates.Lat})
+		}
+
+		collection.Features = append(collection.Features, geoJSONFeature{
+			Type:     "Feature",
+			Geometry: geoJSONGeometry{Type: "LineString", Coordinates: line},
+			Properties: map[string]any{
+				"kind":    "route",
+				"vehicle": it.Vehicle + 1,
+				"driver":  it.Driver,
+				"stops":   len(line) - 1,
+				"color":   color,
+				"stroke":  color,
+			},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(collection)
+}
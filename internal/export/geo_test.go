@@ -0,0 +1,152 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// geoItineraries is two vehicles from one depot. The first has two guests
+// at its first stop, which the writers show as one location.
+func geoItineraries() []Itinerary {
+	depot := coordinates.GuestCoordinates{Long: -75.73, Lat: 45.40}
+	return []Itinerary{
+		{
+			Vehicle: 0,
+			Driver:  "Mina",
+			Depot:   depot,
+			Stops: []Stop{
+				{Number: 1, Name: "Sara", GroupSize: 2, Address: "1 Main St",
+					Coordinates: coordinates.GuestCoordinates{Long: -75.70, Lat: 45.42}},
+				{Number: 1, Name: "Jo & Lee", GroupSize: 1, Address: "1 Main St",
+					Coordinates: coordinates.GuestCoordinates{Long: -75.70, Lat: 45.42}},
+				{Number: 2, Name: "Ahmed", GroupSize: 1, Address: "3 Elm St",
+					Coordinates: coordinates.GuestCoordinates{Long: -75.68, Lat: 45.41}},
+			},
+		},
+		{
+			Vehicle: 3,
+			Driver:  "Driver 4",
+			Depot:   depot,
+			Stops: []Stop{
+				{Number: 1, Name: "Zoë", GroupSize: 3, Address: "12 Rue Saint-Émile",
+					Coordinates: coordinates.GuestCoordinates{Long: -75.75, Lat: 45.38}},
+			},
+		},
+	}
+}
+
+// golden compares got with testdata/name, rewriting it under -update.
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s differs from the golden file:\n%s", name, got)
+	}
+}
+
+var geoWriters = map[string]func(io.Writer, []Itinerary, string) error{
+	"gpx":     WriteGPX,
+	"kml":     WriteKML,
+	"geojson": WriteGeoJSON,
+}
+
+func TestGeoGolden(t *testing.T) {
+	for ext, write := range geoWriters {
+		var routes, empty bytes.Buffer
+		if err := write(&routes, geoItineraries(), "Dinner June 12"); err != nil {
+			t.Fatalf("%s: %v", ext, err)
+		}
+		golden(t, "routes."+ext, routes.Bytes())
+
+		if err := write(&empty, nil, "Dinner June 12"); err != nil {
+			t.Fatalf("%s empty: %v", ext, err)
+		}
+		golden(t, "empty."+ext, empty.Bytes())
+	}
+}
+
+func TestGeoMergesStopsAtOneLocation(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteGPX(&buf, geoItineraries(), ""); err != nil {
+		t.Fatal(err)
+	}
+	gpx := buf.String()
+	if n := strings.Count(gpx, "<wpt "); n != 4 {
+		t.Errorf("GPX has %d waypoints, want the depot and 3 locations", n)
+	}
+	if !strings.Contains(gpx, "<name>Mina stop 1: Sara; Jo &amp; Lee</name>") {
+		t.Error("GPX does not name both guests of the shared stop")
+	}
+	if first := strings.Index(gpx, "<wpt "); !strings.HasPrefix(gpx[first:], `<wpt lat="45.4" lon="-75.73">`) ||
+		!strings.Contains(gpx[first:], "<name>Depot</name>") {
+		t.Error("the depot is not the first GPX waypoint")
+	}
+}
+
+func TestKMLColor(t *testing.T) {
+	for name, want := range map[string]string{"red": "ff0000ff", "blue": "ffff0000", "orange": "ff00a5ff", "brown": "ff13458b"} {
+		if got := kmlColor(name); got != want {
+			t.Errorf("kmlColor(%q) = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestGeoJSONCoordinateOrder(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteGeoJSON(&buf, geoItineraries(), ""); err != nil {
+		t.Fatal(err)
+	}
+	var collection struct {
+		Features []struct {
+			Geometry struct {
+				Type        string
+				Coordinates json.RawMessage
+			}
+			Properties map[string]any
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &collection); err != nil {
+		t.Fatal(err)
+	}
+
+	depot := collection.Features[0]
+	var point []float64
+	if err := json.Unmarshal(depot.Geometry.Coordinates, &point); err != nil {
+		t.Fatal(err)
+	}
+	if depot.Properties["kind"] != "depot" || len(point) != 2 || point[0] != -75.73 || point[1] != 45.40 {
+		t.Errorf("first feature is %v at %v, want the depot at [lon, lat]", depot.Properties["kind"], point)
+	}
+
+	var stops int
+	for _, f := range collection.Features {
+		if f.Properties["kind"] == "stop" {
+			stops++
+		}
+	}
+	if stops != 3 {
+		t.Errorf("GeoJSON has %d stops, want 3 locations", stops)
+	}
+}
@@ -0,0 +1,87 @@
+package export
+
+import (
+	"github.com/andrew-tawfik/outreach-routing/internal/app"
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+)
+
+// Stop is one guest on a driver's itinerary. Guests at the same location
+// share a stop number.
+type Stop struct {
+	Number      int
+	Name        string
+	GroupSize   int
+	Address     string
+	PhoneNumber string
+	Notes       string
+	Coordinates coordinates.GuestCoordinates
+	Waze        string
+}
+
+// Itinerary is everything a driver needs for their route, in stop order.
+// Directions holds the Google Maps links for the whole route.
+type Itinerary struct {
+	Vehicle    int
+	Driver     string
+	Depot      coordinates.GuestCoordinates
+	Stops      []Stop
+	Directions []string
+}
+
+// People is the number of guests served on the itinerary.
+func (it Itinerary) People() int {
+	people := 0
+	for _, s := range it.Stops {
+		people += s.GroupSize
+	}
+	return people
+}
+
+// Locations returns the distinct stop locations in visiting order.
+func (it Itinerary) Locations() []coordinates.GuestCoordinates {
+	locations := make([]coordinates.GuestCoordinates, 0, len(it.Stops))
+	last := 0
+	for _, s := range it.Stops {
+		if s.Number != last {
+			locations = append(locations, s.Coordinates)
+			last = s.Number
+		}
+	}
+	return locations
+}
+
+// Itineraries builds one itinerary per vehicle that has guests.
+func Itineraries(rm *app.RouteManager, lr *app.LocationRegistry) []Itinerary {
+	itineraries := make([]Itinerary, 0, len(rm.Vehicles))
+	for i := range rm.Vehicles {
+		v := &rm.Vehicles[i]
+		if len(v.Guests) == 0 {
+			continue
+		}
+
+		stopNumbers := v.StopNumbers()
+		stops := make([]Stop, len(v.Guests))
+		for j := range v.Guests {
+			g := &v.Guests[j]
+			stops[j] = Stop{
+				Number:      stopNumbers[j],
+				Name:        g.Name,
+				GroupSize:   g.GroupSize,
+				Address:     g.FullAddress(),
+				PhoneNumber: g.PhoneNumber,
+				Notes:       g.Notes,
+				Coordinates: g.Coordinates,
+				Waze:        app.WazeURL(g.Coordinates),
+			}
+		}
+
+		itineraries = append(itineraries, Itinerary{
+			Vehicle:    i,
+			Driver:     v.DriverName(i),
+			Depot:      lr.Depot,
+			Stops:      stops,
+			Directions: v.DirectionsURLs(lr.Depot),
+		})
+	}
+	return itineraries
+}
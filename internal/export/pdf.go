@@ -0,0 +1,263 @@
+package export
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+	"github.com/go-pdf/fpdf"
+)
+
+// The itineraries are set in DejaVu Sans, which covers the accented and
+// non-Latin names on the guest list.
+var (
+	//go:embed fonts/DejaVuSansCondensed.ttf
+	regularFont []byte
+	//go:embed fonts/DejaVuSansCondensed-Bold.ttf
+	boldFont []byte
+)
+
+const fontFamily = "DejaVu"
+
+// PDFOptions describes the event printed at the top of every page.
+type PDFOptions struct {
+	Title string
+}
+
+const (
+	pageMargin = 12.0
+	rowHeight  = 5.5
+	mapHeight  = 95.0
+)
+
+var (
+	tableHeaders = []string{"#", "Name", "Address", "Phone", "People", "Notes", "Nav"}
+	tableWidths  = []float64{9, 40, 56, 28, 13, 33, 12}
+)
+
+// WriteItineraryPDF writes a booklet with one page per driver.
+func WriteItineraryPDF(w io.Writer, itineraries []Itinerary, opts PDFOptions) error {
+	pdf := newItineraryPDF(opts)
+	for _, it := range itineraries {
+		writeItineraryPage(pdf, it, opts)
+	}
+	if len(itineraries) == 0 {
+		pdf.AddPage()
+		pdf.CellFormat(0, rowHeight, "No routes to print.", "", 1, "L", false, 0, "")
+	}
+	return pdf.Output(w)
+}
+
+// WriteItineraryPDFs writes a separate PDF for each driver into dir and
+// returns the paths written.
+func WriteItineraryPDFs(dir string, itineraries []Itinerary, opts PDFOptions) ([]string, error) {
+	paths := make([]string, 0, len(itineraries))
+	for _, it := range itineraries {
+		path := filepath.Join(dir, fileName(it.Driver)+".pdf")
+		f, err := os.Create(path)
+		if err != nil {
+			return paths, fmt.Errorf("cannot create %s: %w", path, err)
+		}
+
+		err = WriteItineraryPDF(f, []Itinerary{it}, opts)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return paths, fmt.Errorf("cannot write %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func fileName(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), "-"))
+}
+
+func newItineraryPDF(opts PDFOptions) *fpdf.Fpdf {
+	pdf := fpdf.New("P", "mm", "Letter", "")
+	pdf.SetMargins(pageMargin, pageMargin, pageMargin)
+	pdf.SetAutoPageBreak(true, pageMargin)
+	pdf.SetTitle(opts.Title, true)
+	pdf.SetCreator("Outreach Routing", true)
+	pdf.AddUTF8FontFromBytes(fontFamily, "", regularFont)
+	pdf.AddUTF8FontFromBytes(fontFamily, "B", boldFont)
+	return pdf
+}
+
+func writeItineraryPage(pdf *fpdf.Fpdf, it Itinerary, opts PDFOptions) {
+	pdf.AddPage()
+	pdf.Bookmark(it.Driver, 0, -1)
+
+	pdf.SetFont(fontFamily, "B", 18)
+	pdf.CellFormat(0, 9, it.Driver, "", 1, "L", false, 0, "")
+
+	pdf.SetFont(fontFamily, "", 10)
+	summary := fmt.Sprintf("%d stops", len(it.Locations()))
+	if people := it.People(); people > 0 {
+		summary += fmt.Sprintf(", %d people", people)
+	}
+	if opts.Title != "" {
+		summary = opts.Title + "  -  " + summary
+	}
+	pdf.CellFormat(0, 6, summary, "", 1, "L", false, 0, "")
+	pdf.Ln(3)
+
+	pdf.SetFont(fontFamily, "B", 9)
+	pdf.SetFillColor(230, 230, 240)
+	writeTableRow(pdf, tableHeaders, nil, true)
+
+	pdf.SetFont(fontFamily, "", 9)
+	for _, s := range it.Stops {
+		people := "-"
+		if s.GroupSize > 0 {
+			people = strconv.Itoa(s.GroupSize)
+		}
+		phone := s.PhoneNumber
+		if phone == "" {
+			phone = "No number"
+		}
+		cells := []string{
+			strconv.Itoa(s.Number), s.Name, s.Address, phone, people, s.Notes, "Waze",
+		}
+		links := make([]string, len(cells))
+		links[len(links)-1] = s.Waze
+		writeTableRow(pdf, cells, links, false)
+	}
+
+	pdf.Ln(4)
+	for i, link := range it.Directions {
+		label := "Google Maps directions"
+		if len(it.Directions) > 1 {
+			label = fmt.Sprintf("Google Maps directions, part %d of %d", i+1, len(it.Directions))
+		}
+		pdf.SetFont(fontFamily, "B", 9)
+		pdf.CellFormat(0, rowHeight, label, "", 1, "L", false, 0, "")
+		pdf.SetFont(fontFamily, "U", 8)
+		pdf.SetTextColor(30, 60, 200)
+		top := pdf.GetY()
+		pdf.MultiCell(sum(tableWidths), 4, link, "", "L", false)
+		pdf.LinkString(pageMargin, top, sum(tableWidths), pdf.GetY()-top, link)
+		pdf.SetTextColor(0, 0, 0)
+	}
+
+	_, pageHeight := pdf.GetPageSize()
+	if pdf.GetY()+mapHeight+6 > pageHeight-pageMargin {
+		pdf.AddPage()
+	}
+	pdf.Ln(6)
+	drawRouteMap(pdf, it, pageMargin, pdf.GetY(), sum(tableWidths), mapHeight)
+}
+
+// writeTableRow writes one table row, wrapping long cells and giving every
+// cell the height of the tallest one. A non-empty entry in links makes the
+// matching cell a clickable link.
+func writeTableRow(pdf *fpdf.Fpdf, cells, links []string, fill bool) {
+	lines := make([][]string, len(cells))
+	height := 1
+	for i, cell := range cells {
+		lines[i] = pdf.SplitText(cell, tableWidths[i]-2)
+		if len(lines[i]) > height {
+			height = len(lines[i])
+		}
+	}
+
+	_, pageHeight := pdf.GetPageSize()
+	if pdf.GetY()+float64(height)*rowHeight > pageHeight-pageMargin {
+		pdf.AddPage()
+	}
+
+	style := "D"
+	if fill {
+		style = "FD"
+	}
+
+	x, y := pdf.GetXY()
+	for i := range cells {
+		link := ""
+		if i < len(links) {
+			link = links[i]
+		}
+		pdf.Rect(x, y, tableWidths[i], float64(height)*rowHeight, style)
+		for j, l := range lines[i] {
+			pdf.SetXY(x, y+float64(j)*rowHeight)
+			pdf.CellFormat(tableWidths[i], rowHeight, l, "", 0, "L", false, 0, link)
+		}
+		x += tableWidths[i]
+	}
+	pdf.SetXY(pageMargin, y+float64(height)*rowHeight)
+}
+
+// drawRouteMap sketches the route inside the given box: the depot as a
+// square and each stop as a numbered circle, joined in visiting order. The
+// projection scales longitude by the cosine of the latitude so distances
+// look right at Ottawa's latitude.
+func drawRouteMap(pdf *fpdf.Fpdf, it Itinerary, x, y, w, h float64) {
+	points := append([]coordinates.GuestCoordinates{it.Depot}, it.Locations()...)
+
+	minX, maxX := math.Inf(1), math.Inf(-1)
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	scale := math.Cos(it.Depot.Lat * math.Pi / 180)
+	for _, p := range points {
+		px := p.Long * scale
+		minX, maxX = math.Min(minX, px), math.Max(maxX, px)
+		minY, maxY = math.Min(minY, p.Lat), math.Max(maxY, p.Lat)
+	}
+
+	const padding = 10.0
+	spanX, spanY := math.Max(maxX-minX, 1e-4), math.Max(maxY-minY, 1e-4)
+	ratio := math.Min((w-2*padding)/spanX, (h-2*padding)/spanY)
+	offsetX := x + (w-spanX*ratio)/2
+	offsetY := y + (h-spanY*ratio)/2
+
+	project := func(p coordinates.GuestCoordinates) (float64, float64) {
+		return offsetX + (p.Long*scale-minX)*ratio, offsetY + (maxY-p.Lat)*ratio
+	}
+
+	pdf.SetDrawColor(160, 160, 170)
+	pdf.SetLineWidth(0.2)
+	pdf.Rect(x, y, w, h, "D")
+
+	pdf.SetDrawColor(55, 48, 163)
+	pdf.SetLineWidth(0.8)
+	for i := 1; i < len(points); i++ {
+		x1, y1 := project(points[i-1])
+		x2, y2 := project(points[i])
+		pdf.Line(x1, y1, x2, y2)
+	}
+
+	pdf.SetLineWidth(0.2)
+	pdf.SetFont(fontFamily, "B", 8)
+	pdf.SetFillColor(55, 48, 163)
+	pdf.SetTextColor(255, 255, 255)
+	for i, p := range points {
+		px, py := project(p)
+		label := strconv.Itoa(i)
+		if i == 0 {
+			label = "D"
+			pdf.Rect(px-2.5, py-2.5, 5, 5, "F")
+		} else {
+			pdf.Circle(px, py, 2.8, "F")
+		}
+		pdf.SetXY(px-2.5, py-2.5)
+		pdf.CellFormat(5, 5, label, "", 0, "C", false, 0, "")
+	}
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.SetXY(x, y+h)
+}
+
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
@@ -0,0 +1,34 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+)
+
+func TestWriteItineraryPDFUnicodeNames(t *testing.T) {
+	depot := coordinates.GuestCoordinates{Long: -75.73, Lat: 45.40}
+	it := Itinerary{
+		Driver: "Driver 1",
+		Depot:  depot,
+		Stops: []Stop{
+			{Number: 1, Name: "Zoë Ćwikła", GroupSize: 2, Address: "12 Rue Saint-Émile",
+				Coordinates: coordinates.GuestCoordinates{Long: -75.70, Lat: 45.42}},
+			{Number: 2, Name: "Ахмед Σοφία 李", GroupSize: 1, Address: "3 Elm St", Notes: "Buzz #2 – côté cour",
+				Coordinates: coordinates.GuestCoordinates{Long: -75.68, Lat: 45.41}},
+		},
+		Directions: []string{"https://www.google.com/maps/dir/?api=1"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteItineraryPDF(&buf, []Itinerary{it}, PDFOptions{Title: "Dîner June 12"}); err != nil {
+		t.Fatalf("WriteItineraryPDF: %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+		t.Errorf("output is not a PDF")
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("/BaseFont /utf8dejavu")) || bytes.Contains(buf.Bytes(), []byte("Helvetica")) {
+		t.Errorf("PDF is not set in the embedded DejaVu font")
+	}
+}
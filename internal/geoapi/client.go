@@ -0,0 +1,133 @@
+package geoapi
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var httpClient = &http.Client{Timeout: 30 * time.Second, Transport: transport}
+
+const (
+	maxAttempts   = 4
+	baseBackoff   = 500 * time.Millisecond
+	maxBackoff    = 8 * time.Second
+	maxRetryAfter = 60 * time.Second
+)
+
+// sleep waits for d or until ctx is done, whichever comes first. Tests
+// replace it to run without real delays.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// sendWithRetry performs req, retrying transport failures, 429 and 5xx
+// responses with exponential backoff and jitter. A Retry-After header from the
+// server takes precedence over the computed delay. Waits end early when the
+// request's context is cancelled, and are skipped while replaying a
+// recording. Any other non-200 response is returned immediately as a
+// *RequestError.
+func sendWithRetry(req *http.Request) (*http.Response, error) {
+	var lastErr error
+	wait := func(d time.Duration) error {
+		if isReplaying() {
+			return nil
+		}
+		return sleep(req.Context(), d)
+	}
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if err := req.Context().Err(); err != nil {
+			return nil, &RequestError{Kind: FailureNetwork, Host: req.URL.Host, Err: err}
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			lastErr = &RequestError{Kind: FailureNetwork, Host: req.URL.Host, Err: err}
+			if attempt < maxAttempts-1 {
+				if err := wait(backoff(attempt)); err != nil {
+					return nil, &RequestError{Kind: FailureNetwork, Host: req.URL.Host, Err: err}
+				}
+			}
+			continue
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			return resp, nil
+		}
+
+		reqErr := classifyStatus(req.URL.Host, resp.StatusCode)
+		resp.Body.Close()
+		lastErr = reqErr
+
+		if !retryableStatus(resp.StatusCode) {
+			return nil, reqErr
+		}
+
+		delay := backoff(attempt)
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			if wait > maxRetryAfter {
+				return nil, reqErr
+			}
+			delay = wait
+		}
+		if attempt < maxAttempts-1 {
+			if err := wait(delay); err != nil {
+				return nil, &RequestError{Kind: FailureNetwork, Host: req.URL.Host, Err: err}
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("request to %s failed after %d attempts: %w", req.URL.Host, maxAttempts, lastErr)
+}
+
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+// backoff returns the delay before the retry following attempt, doubling from
+// baseBackoff up to maxBackoff. Half of the delay is randomised so that
+// concurrent clients do not retry in lockstep.
+func backoff(attempt int) time.Duration {
+	d := baseBackoff << attempt
+	if d <= 0 || d > maxBackoff {
+		d = maxBackoff
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter reads a Retry-After header given either as delay-seconds or
+// as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		wait := at.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
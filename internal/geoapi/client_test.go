@@ -0,0 +1,180 @@
+package geoapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeSleep replaces sleep for the duration of the test and records the
+// requested delays.
+func fakeSleep(t *testing.T) *[]time.Duration {
+	t.Helper()
+	var delays []time.Duration
+	saved := sleep
+	sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return ctx.Err()
+	}
+	t.Cleanup(func() { sleep = saved })
+	return &delays
+}
+
+func newRequest(t *testing.T, ctx context.Context, url string) *http.Request {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+func TestSendWithRetryRecoversFromServerErrors(t *testing.T) {
+	delays := fakeSleep(t)
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	resp, err := sendWithRetry(newRequest(t, context.Background(), srv.URL))
+	if err != nil {
+		t.Fatalf("sendWithRetry: %v", err)
+	}
+	resp.Body.Close()
+	if calls.Load() != 3 {
+		t.Errorf("calls = %d, want 3", calls.Load())
+	}
+	if len(*delays) != 2 {
+		t.Fatalf("slept %d times, want 2", len(*delays))
+	}
+	for i, d := range *delays {
+		if max := baseBackoff << i; d < max/2 || d > max {
+			t.Errorf("delay %d = %v, want between %v and %v", i, d, max/2, max)
+		}
+	}
+}
+
+func TestSendWithRetryHonoursRetryAfter(t *testing.T) {
+	delays := fakeSleep(t)
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	resp, err := sendWithRetry(newRequest(t, context.Background(), srv.URL))
+	if err != nil {
+		t.Fatalf("sendWithRetry: %v", err)
+	}
+	resp.Body.Close()
+	if len(*delays) != 1 || (*delays)[0] != 7*time.Second {
+		t.Errorf("delays = %v, want [7s]", *delays)
+	}
+}
+
+func TestSendWithRetryGivesUpOnLongRetryAfter(t *testing.T) {
+	delays := fakeSleep(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	_, err := sendWithRetry(newRequest(t, context.Background(), srv.URL))
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) || reqErr.Kind != FailureQuota {
+		t.Fatalf("err = %v, want quota error", err)
+	}
+	if len(*delays) != 0 {
+		t.Errorf("slept %v, want no wait", *delays)
+	}
+}
+
+func TestSendWithRetryDoesNotRetryClientErrors(t *testing.T) {
+	delays := fakeSleep(t)
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	_, err := sendWithRetry(newRequest(t, context.Background(), srv.URL))
+	if failureKind(err) != FailureAuth {
+		t.Errorf("err = %v, want auth error", err)
+	}
+	if calls.Load() != 1 || len(*delays) != 0 {
+		t.Errorf("calls = %d, delays = %v, want one call and no wait", calls.Load(), *delays)
+	}
+}
+
+func TestSendWithRetryNoWaitAfterLastAttempt(t *testing.T) {
+	delays := fakeSleep(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	url := srv.URL
+	srv.Close()
+
+	_, err := sendWithRetry(newRequest(t, context.Background(), url))
+	if failureKind(err) != FailureNetwork {
+		t.Errorf("err = %v, want network error", err)
+	}
+	if len(*delays) != maxAttempts-1 {
+		t.Errorf("slept %d times, want %d", len(*delays), maxAttempts-1)
+	}
+}
+
+func TestSendWithRetryStopsWhenCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := sendWithRetry(newRequest(t, ctx, srv.URL))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if waited := time.Since(start); waited > 5*time.Second {
+		t.Errorf("waited %v after cancel", waited)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"12", 12 * time.Second, true},
+		{"-1", 0, false},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package geoapi
+
+import (
+	"fmt"
+	"sort"
+)
+
+type Confidence int
+
+const (
+	ConfidenceLow Confidence = iota
+	ConfidenceMedium
+	ConfidenceHigh
+)
+
+func (c Confidence) String() string {
+	switch c {
+	case ConfidenceHigh:
+		return "High"
+	case ConfidenceMedium:
+		return "Medium"
+	default:
+		return "Low"
+	}
+}
+
+// ReviewError is returned when the best geocoding match is not reliable
+// enough to route to without a coordinator confirming it.
+type ReviewError struct {
+	Candidates []Candidate
+}
+
+func (e *ReviewError) Error() string {
+	if len(e.Candidates) == 0 {
+		return "low confidence match, please review"
+	}
+	best := e.Candidates[0]
+	return fmt.Sprintf("low confidence match, please review: %s (%s)", best.Address, best.Describe())
+}
+
+func (e *ReviewError) Is(target error) bool {
+	return target == ErrNeedsReview
+}
+
+// assessConfidence grades a Google geocoding result. Rooftop matches on a
+// street address are trusted; interpolated or partial matches are usable but
+// less precise; anything resolving to a whole street or area needs review.
+func assessConfidence(r GeocodeResult) Confidence {
+	precise := hasAnyType(r.Types, "street_address", "premise", "subpremise")
+
+	switch r.Geometry.LocationType {
+	case "ROOFTOP":
+		if precise && !r.PartialMatch {
+			return ConfidenceHigh
+		}
+		return ConfidenceMedium
+	case "RANGE_INTERPOLATED":
+		if r.PartialMatch {
+			return ConfidenceLow
+		}
+		return ConfidenceMedium
+	default:
+		return ConfidenceLow
+	}
+}
+
+func hasAnyType(types []string, wanted ...string) bool {
+	for _, t := range types {
+		for _, w := range wanted {
+			if t == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Describe summarises why a candidate received its confidence level.
+func (c Candidate) Describe() string {
+	desc := fmt.Sprintf("%s confidence, %s", c.Confidence, locationTypeLabel(c.LocationType))
+	if c.PartialMatch {
+		desc += ", partial match"
+	}
+	desc = fmt.Sprintf("%s, %.1f km from depot", desc, c.DistanceKm)
+	if c.OutsideArea != "" {
+		desc += ", outside service area"
+	}
+	return desc
+}
+
+func locationTypeLabel(locationType string) string {
+	switch locationType {
+	case "ROOFTOP":
+		return "rooftop"
+	case "RANGE_INTERPOLATED":
+		return "interpolated"
+	case "GEOMETRIC_CENTER":
+		return "centre of area"
+	case "APPROXIMATE":
+		return "approximate"
+	case "":
+		return "unknown precision"
+	default:
+		return locationType
+	}
+}
+
+// rankCandidates orders candidates from most to least trustworthy, keeping
+// the geocoder's own order between candidates of equal confidence.
+func rankCandidates(candidates []Candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ci, cj := candidates[i], candidates[j]
+		if (ci.OutsideArea == "") != (cj.OutsideArea == "") {
+			return ci.OutsideArea == ""
+		}
+		return ci.Confidence > cj.Confidence
+	})
+}
@@ -6,28 +6,28 @@ import (
 	"io"
 	"net/http"
 	"strings"
-	"time"
-
-	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
 )
 
-var httpClient = &http.Client{Timeout: 30 * time.Second}
-
-
-func retreiveAddressCoordinate(address string) (coordinates.GuestCoordinates, error) {
+// retreiveAddressCoordinate geocodes address with Nominatim and returns the
+// best match in Ottawa, or a *ReviewError carrying the matches when the best
+// one has low confidence.
+func retreiveAddressCoordinate(address string) (Candidate, error) {
 	url := buildGeocodeURL(address)
 
 	body, err := fetchGeocodeData(url)
 	if err != nil {
-		return coordinates.GuestCoordinates{}, err
+		return Candidate{}, err
 	}
 
-	coor, err := parseGeocodeResponse(body, "Ottawa") // Match based on city keyword
+	candidates, err := parseGeocodeResponse(body, "Ottawa") // Match based on city keyword
 	if err != nil {
-		return coordinates.GuestCoordinates{}, err
+		return Candidate{}, err
 	}
 
-	return coordinates.GuestCoordinates{Long: coor[0], Lat: coor[1]}, nil
+	if candidates[0].Confidence == ConfidenceLow {
+		return Candidate{}, &ReviewError{Candidates: candidates}
+	}
+	return candidates[0], nil
 }
 
 func (g *Guest) geocodeGuestAddress() error {
@@ -36,14 +36,13 @@ func (g *Guest) geocodeGuestAddress() error {
 	if err != nil {
 		return err
 	}
-	gc, newAddr, err := retreiveGuestLocation(g.Address, apiKey)
-	// TODO  add the formatted address as this will be a more complete address string
+	match, err := retreiveGuestLocation(g.Address, apiKey)
 	if err != nil {
-		fmt.Println("there was an error: ", err)
 		return err
 	}
-	g.Coordinates = gc
-	g.Address = newAddr
+	g.Coordinates = match.Coordinates
+	g.Address = match.Address
+	g.Confidence = match.Confidence
 	return nil
 }
 
@@ -72,56 +71,24 @@ func fetchGeocodeData(url string) ([]byte, error) {
 	return body, nil
 }
 
-func parseGeocodeResponse(body []byte, city string) ([]float64, error) {
+func parseGeocodeResponse(body []byte, city string) ([]Candidate, error) {
 	var nr NominatimResponse
 	if err := json.Unmarshal(body, &nr); err != nil {
 		return nil, fmt.Errorf("could not deserialize response body: %v", err)
 	}
 
-	coordinates, err := nr.locateCoordinatesByKeyword(city)
+	candidates, err := nr.candidatesByKeyword(city)
 	if err != nil {
-		return nil, fmt.Errorf("could not extract coordinates, %v", err)
+		return nil, fmt.Errorf("could not extract coordinates, %w", err)
 	}
-	return coordinates, nil
+	return candidates, nil
 }
 
 
 func polishAddress(rawAddress *string) {
 	address := strings.ToLower(*rawAddress)
-
-	if i := strings.Index(address, "apt"); i != -1 {
-		address = address[:i]
-	} else if i := strings.Index(address, "unit"); i != -1 {
-		address = address[:i]
-	}
-
 	address = strings.TrimSpace(address)
 	address = strings.ReplaceAll(address, " ", "+")
 
 	*rawAddress = address
 }
-
-func sendWithRetry(req *http.Request) (*http.Response, error) {
-	const maxAttempts = 3
-
-	for attempts := 0; attempts < maxAttempts; attempts++ {
-		resp, err := httpClient.Do(req)
-		if err != nil {
-			fmt.Printf("HTTP request failed for %s: %v\n", req.URL.String(), err)
-			return nil, err
-		}
-
-		if resp.StatusCode == http.StatusOK {
-			return resp, nil
-		} else {
-			resp.Body.Close()
-			time.Sleep(2 * time.Second)
-			continue
-		}
-
-		resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	return nil, fmt.Errorf("request to %s failed after %d attempts", req.URL.String(), maxAttempts)
-}
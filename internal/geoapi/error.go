@@ -1,10 +1,140 @@
 package geoapi
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 )
 
+type FailureKind int
+
+const (
+	FailureUnknown FailureKind = iota
+	FailureQuota
+	FailureAuth
+	FailureNotFound
+	FailureNetwork
+	FailureNeedsReview
+	FailureOutsideArea
+)
+
+func (k FailureKind) String() string {
+	switch k {
+	case FailureQuota:
+		return "Quota exceeded"
+	case FailureAuth:
+		return "Authorization failed"
+	case FailureNotFound:
+		return "Not found"
+	case FailureNetwork:
+		return "Network failure"
+	case FailureNeedsReview:
+		return "Needs review"
+	case FailureOutsideArea:
+		return "Outside service area"
+	default:
+		return "Unexpected error"
+	}
+}
+
+var (
+	ErrQuota    = errors.New("request quota exceeded")
+	ErrAuth     = errors.New("request was not authorized")
+	ErrNotFound = errors.New("no matching result")
+	ErrNetwork  = errors.New("network failure")
+
+	ErrNeedsReview = errors.New("geocoding match needs review")
+	ErrOutsideArea = errors.New("geocoding match is outside the service area")
+)
+
+func (k FailureKind) sentinel() error {
+	switch k {
+	case FailureQuota:
+		return ErrQuota
+	case FailureAuth:
+		return ErrAuth
+	case FailureNotFound:
+		return ErrNotFound
+	case FailureNetwork:
+		return ErrNetwork
+	case FailureNeedsReview:
+		return ErrNeedsReview
+	case FailureOutsideArea:
+		return ErrOutsideArea
+	default:
+		return nil
+	}
+}
+
+// RequestError describes a failed call to one of the external APIs. It
+// matches the corresponding Err* sentinel with errors.Is.
+type RequestError struct {
+	Kind   FailureKind
+	Host   string
+	Status int
+	Detail string
+	Err    error
+}
+
+func (e *RequestError) Error() string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(e.Kind.String()))
+	if e.Host != "" {
+		b.WriteString(" (" + e.Host + ")")
+	}
+	if e.Status != 0 {
+		b.WriteString(fmt.Sprintf(": HTTP %d", e.Status))
+	}
+	if e.Detail != "" {
+		b.WriteString(": " + e.Detail)
+	}
+	if e.Err != nil {
+		b.WriteString(": " + e.Err.Error())
+	}
+	return b.String()
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+func (e *RequestError) Is(target error) bool {
+	s := e.Kind.sentinel()
+	return s != nil && target == s
+}
+
+func classifyStatus(host string, code int) *RequestError {
+	kind := FailureUnknown
+	switch {
+	case code == http.StatusTooManyRequests:
+		kind = FailureQuota
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		kind = FailureAuth
+	case code == http.StatusNotFound:
+		kind = FailureNotFound
+	case code >= 500:
+		kind = FailureNetwork
+	}
+	return &RequestError{Kind: kind, Host: host, Status: code}
+}
+
+// failureKind reports the FailureKind carried by err, or FailureUnknown when
+// err did not come from a classified request.
+func failureKind(err error) FailureKind {
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.Kind
+	}
+	if errors.Is(err, ErrNeedsReview) {
+		return FailureNeedsReview
+	}
+	if errors.Is(err, ErrOutsideArea) {
+		return FailureOutsideArea
+	}
+	return FailureUnknown
+}
+
 type ApiErrors struct {
 	FailedGuests []FailedGuest
 }
@@ -12,18 +142,68 @@ type ApiErrors struct {
 type FailedGuest struct {
 	Name    string
 	Address string
+	Kind    FailureKind
 	Reason  string
+	Guest   Guest
+
+	Candidates []Candidate
+}
+
+func NewFailedGuest(g Guest, err error) FailedGuest {
+	fg := FailedGuest{
+		Name:    g.Name,
+		Address: g.Address,
+		Kind:    failureKind(err),
+		Reason:  err.Error(),
+		Guest:   g,
+	}
+
+	var reviewErr *ReviewError
+	if errors.As(err, &reviewErr) {
+		fg.Candidates = reviewErr.Candidates
+	}
+	return fg
 }
 
 func (ae *ApiErrors) HasErrors() bool {
 	return len(ae.FailedGuests) > 0
 }
 
+func (ae *ApiErrors) countKind(kind FailureKind) int {
+	count := 0
+	for _, fg := range ae.FailedGuests {
+		if fg.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
 func (ae *ApiErrors) GetSummary() string {
 	if !ae.HasErrors() {
 		return ""
 	}
 
+	if ae.countKind(FailureAuth) > 0 {
+		return "The mapping service rejected our API key, please check the maps configuration"
+	}
+
+	if n := ae.countKind(FailureQuota); n > 0 {
+		return fmt.Sprintf("Mapping service quota reached for %d guests, please try again later", n)
+	}
+
+	if n := ae.countKind(FailureNetwork); n > 0 {
+		return fmt.Sprintf("Could not reach the mapping service for %d guests, please check your connection", n)
+	}
+
+	if n := ae.countKind(FailureOutsideArea); n > 0 {
+		return fmt.Sprintf("%d addresses matched outside the service area, please correct them", n)
+	}
+
+	if n := ae.countKind(FailureNeedsReview); n == len(ae.FailedGuests) {
+		return fmt.Sprintf("%d addresses matched with low confidence, please review them before routing", n)
+	}
+
 	if len(ae.FailedGuests) == 1 {
 		return fmt.Sprintf("Could not find address for %s at this time, please add guest manually ", ae.FailedGuests[0].Name)
 	}
@@ -22,11 +22,17 @@ const (
 
 type Guest struct {
 	Status      GuestStatus
+	ID          string
 	Name        string
 	GroupSize   int
 	Address     string
 	Coordinates coordinates.GuestCoordinates
 	PhoneNumber string
+	Unit        string
+	Notes       string
+	Extra       map[string]string
+	Confidence  Confidence
+	Row         int
 }
 
 
@@ -41,6 +47,7 @@ type Event struct {
 type LocationRegistry struct {
 	DistanceMatrix [][]float64
 	CoordianteMap  CoordinateMapping
+	Depot          coordinates.GuestCoordinates
 }
 
 
@@ -78,11 +85,13 @@ func (e *Event) RequestGuestCoordiantes() error {
 
 	
 	depotAddr := "555 Parkdale Ave"
-	depotCoor, err := retreiveAddressCoordinate(depotAddr)
+	depot, err := retreiveAddressCoordinate(depotAddr)
 	if err != nil {
 		return fmt.Errorf("failed to geocode SMSM address: %w", err)
 	}
+	depotCoor := depot.Coordinates
 	e.GuestLocations.CoordianteMap.AddressOrder = append(e.GuestLocations.CoordianteMap.AddressOrder, depotAddr)
+	e.GuestLocations.Depot = depotCoor
 
 	depotCoorString := depotCoor.ToString()
 	addToCoordListString(&depotCoorString)
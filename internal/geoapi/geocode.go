@@ -2,8 +2,9 @@ package geoapi
 
 
 type GoogleGeocodeResponse struct {
-	Results []GeocodeResult `json:"results"`
-	Status  string          `json:"status"`
+	Results      []GeocodeResult `json:"results"`
+	Status       string          `json:"status"`
+	ErrorMessage string          `json:"error_message"`
 }
 
 
@@ -13,6 +14,7 @@ type GeocodeResult struct {
 	Geometry          GeocodeGeometry    `json:"geometry"`
 	PlaceID           string             `json:"place_id"`
 	Types             []string           `json:"types"`
+	PartialMatch      bool               `json:"partial_match"`
 }
 
 
@@ -8,7 +8,6 @@ import (
 	"path/filepath"
 
 	"github.com/andrew-tawfik/outreach-routing/internal/config"
-	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
 )
 
 var geocodeMapsBaseURL string = "https://maps.googleapis.com/maps/api/geocode/json"
@@ -24,11 +23,7 @@ func (e *Event) geocodeEvent() {
 		err := e.Guests[i].geocodeGuestAddress()
 		if err != nil {
 
-			apiErrors.FailedGuests = append(apiErrors.FailedGuests, FailedGuest{
-				Name:    e.Guests[i].Name,
-				Address: e.Guests[i].Address,
-				Reason:  err.Error(),
-			})
+			apiErrors.FailedGuests = append(apiErrors.FailedGuests, NewFailedGuest(e.Guests[i], err))
 			continue
 		}
 		coor, unique := e.isUnique(i)
@@ -43,32 +38,63 @@ func (e *Event) geocodeEvent() {
 
 func buildGeoMapURL(address, apiKey string) string {
 
+	area := serviceArea()
 	params := url.Values{}
-	address += " Ottawa, ON, Canada"
+	if area.AddressSuffix != "" {
+		address += " " + area.AddressSuffix
+	}
 	params.Set("address", address)
+	params.Set("bounds", area.boundsParam())
+	if area.Components != "" {
+		params.Set("components", area.Components)
+	}
 	params.Set("key", apiKey)
 
 	completeURL := geocodeMapsBaseURL + "?" + params.Encode()
 	return completeURL
 }
 
-func retreiveGuestLocation(gAddress, apiKey string) (coordinates.GuestCoordinates, string, error) {
+func retreiveGuestCandidates(gAddress, apiKey string) ([]Candidate, error) {
 	url := buildGeoMapURL(gAddress, apiKey)
 
 	body, err := fetchGeocodeData(url)
 	if err != nil {
-		return coordinates.GuestCoordinates{}, "", err
+		return nil, err
 	}
 
-	coor, newAddr, err := parseGoogleGeocodeResponse(body) 
+	return parseGoogleGeocodeCandidates(body)
+}
+
+// retreiveGuestLocation returns the best match for gAddress inside the
+// service area. It returns an *OutsideAreaError when every match lies
+// outside it, and a *ReviewError carrying the remaining candidates when the
+// best match has low confidence.
+func retreiveGuestLocation(gAddress, apiKey string) (Candidate, error) {
+	candidates, err := retreiveGuestCandidates(gAddress, apiKey)
 	if err != nil {
-		return coordinates.GuestCoordinates{}, "", err
+		return Candidate{}, err
 	}
 
-	return coordinates.GuestCoordinates{Long: coor[0], Lat: coor[1]}, newAddr, nil
+	inside := make([]Candidate, 0, len(candidates))
+	for _, c := range candidates {
+		if c.OutsideArea == "" {
+			inside = append(inside, c)
+		}
+	}
+	if len(inside) == 0 {
+		return Candidate{}, &OutsideAreaError{Address: candidates[0].Address, Reason: candidates[0].OutsideArea}
+	}
+
+	if inside[0].Confidence == ConfidenceLow {
+		return Candidate{}, &ReviewError{Candidates: inside}
+	}
+	return inside[0], nil
 }
 
 func getApiKey() (string, error) {
+	if isReplaying() {
+		return "", nil
+	}
 
 	apiKey, err := config.GetEmbeddedMapsAPIKey()
 	if err == nil && apiKey != "" {
@@ -77,13 +103,13 @@ func getApiKey() (string, error) {
 
 	projectRoot, err := filepath.Abs(filepath.Join(".", ".."))
 	if err != nil {
-		return "", fmt.Errorf("failed to resolve project root:", err)
+		return "", fmt.Errorf("failed to resolve project root: %w", err)
 	}
 	credentialsPath := filepath.Join(projectRoot, "maps_config.json")
 
 	apiKeyFromFile, jsonErr := LoadMapsConfig(credentialsPath)
 	if jsonErr != nil {
-		return "", fmt.Errorf("failed to load api key:", err)
+		return "", fmt.Errorf("failed to load api key: %w", jsonErr)
 	}
 	return apiKeyFromFile, nil
 }
@@ -102,32 +128,24 @@ func LoadMapsConfig(path string) (string, error) {
 	return config.MapsAPIKey, err
 }
 
-func parseGoogleGeocodeResponse(body []byte) ([]float64, string, error) {
-	var response GoogleGeocodeResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, "", fmt.Errorf("could not deserialize response body: %v", err)
+// classifyGeocodeStatus maps the status field of a Geocoding API response,
+// which is returned with HTTP 200, onto a RequestError.
+func classifyGeocodeStatus(status, message string) error {
+	kind := FailureUnknown
+	switch status {
+	case "OVER_QUERY_LIMIT", "OVER_DAILY_LIMIT":
+		kind = FailureQuota
+	case "REQUEST_DENIED":
+		kind = FailureAuth
+	case "ZERO_RESULTS":
+		kind = FailureNotFound
+	case "UNKNOWN_ERROR":
+		kind = FailureNetwork
 	}
 
-	
-	if response.Status != "OK" {
-		return nil, "", fmt.Errorf("geocoding failed with status: %s", response.Status)
+	detail := fmt.Sprintf("geocoding failed with status %s", status)
+	if message != "" {
+		detail += " (" + message + ")"
 	}
-
-	
-	if len(response.Results) == 0 {
-		return nil, "", fmt.Errorf("no geocoding results found")
-	}
-
-	
-	result := response.Results[0]
-
-	
-	coordinates := []float64{
-		result.Geometry.Location.Lng,
-		result.Geometry.Location.Lat,
-	}
-
-	formatted_address := result.FormattedAddress
-
-	return coordinates, formatted_address, nil
+	return &RequestError{Kind: kind, Host: "maps.googleapis.com", Detail: detail}
 }
@@ -0,0 +1,103 @@
+package geoapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+)
+
+// Candidate is one possible match returned by the geocoder for an address.
+type Candidate struct {
+	Address      string
+	Coordinates  coordinates.GuestCoordinates
+	LocationType string
+	Types        []string
+	PartialMatch bool
+	DistanceKm   float64
+	Confidence   Confidence
+	OutsideArea  string
+}
+
+// GeocodeAddress resolves a single address to its most trustworthy match,
+// returning the coordinates and the geocoder's formatted address. Unlike the
+// event geocoding, low confidence matches are accepted since the coordinator
+// asked for this address explicitly, but matches outside the service area
+// are still rejected.
+func GeocodeAddress(address string) (coordinates.GuestCoordinates, string, error) {
+	candidates, err := GeocodeCandidates(address)
+	if err != nil {
+		return coordinates.GuestCoordinates{}, "", err
+	}
+	best := candidates[0]
+	if best.OutsideArea != "" {
+		return coordinates.GuestCoordinates{}, "", &OutsideAreaError{Address: best.Address, Reason: best.OutsideArea}
+	}
+	return best.Coordinates, best.Address, nil
+}
+
+// GeocodeCandidates returns every match the geocoder offers for address,
+// most trustworthy first, so the coordinator can choose between them.
+// Matches outside the service area are included but marked.
+func GeocodeCandidates(address string) ([]Candidate, error) {
+	apiKey, err := getApiKey()
+	if err != nil {
+		return nil, err
+	}
+	return retreiveGuestCandidates(address, apiKey)
+}
+
+func parseGoogleGeocodeCandidates(body []byte) ([]Candidate, error) {
+	var response GoogleGeocodeResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("could not deserialize response body: %v", err)
+	}
+
+	if response.Status != "OK" {
+		return nil, classifyGeocodeStatus(response.Status, response.ErrorMessage)
+	}
+
+	area := serviceArea()
+	candidates := make([]Candidate, 0, len(response.Results))
+	for _, r := range response.Results {
+		coord := coordinates.GuestCoordinates{
+			Long: r.Geometry.Location.Lng,
+			Lat:  r.Geometry.Location.Lat,
+		}
+
+		candidates = append(candidates, Candidate{
+			Address:      r.FormattedAddress,
+			Coordinates:  coord,
+			LocationType: r.Geometry.LocationType,
+			Types:        r.Types,
+			PartialMatch: r.PartialMatch,
+			DistanceKm:   coord.DistanceKm(area.Depot),
+			Confidence:   assessConfidence(r),
+			OutsideArea:  area.check(coord),
+		})
+	}
+
+	if len(candidates) == 0 {
+		return nil, &RequestError{Kind: FailureNotFound, Detail: "no geocoding results found"}
+	}
+	rankCandidates(candidates)
+	return candidates, nil
+}
+
+// FetchDistanceMatrix requests the road distance matrix between coords, in
+// the order given.
+func FetchDistanceMatrix(coords []coordinates.GuestCoordinates) ([][]float64, error) {
+	var list strings.Builder
+	for i := range coords {
+		list.WriteString(coords[i].ToString())
+	}
+	coordList := strings.TrimSuffix(list.String(), ";")
+
+	url := buildDistanceMatrixURL(&coordList)
+	body, err := fetchDistanceMatrix(&url)
+	if err != nil {
+		return nil, err
+	}
+	return parseOsrmResponse(&body)
+}
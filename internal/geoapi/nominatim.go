@@ -2,7 +2,10 @@ package geoapi
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
 )
 
 
@@ -18,7 +21,11 @@ type Feature struct {
 
 
 type Properties struct {
-	DisplayName string `json:"display_name"`
+	DisplayName string  `json:"display_name"`
+	Category    string  `json:"category"`
+	Type        string  `json:"type"`
+	AddressType string  `json:"addresstype"`
+	Importance  float64 `json:"importance"`
 }
 
 
@@ -27,12 +34,56 @@ type Geometry struct {
 }
 
 
-
-func (nr *NominatimResponse) locateCoordinatesByKeyword(keyword string) (coordinates []float64, err error) {
+// candidatesByKeyword returns the features whose name contains keyword as
+// candidates, best first.
+func (nr *NominatimResponse) candidatesByKeyword(keyword string) ([]Candidate, error) {
+	var features []Feature
 	for _, f := range nr.Features {
-		if strings.Contains(f.Properties.DisplayName, keyword) {
-			return f.Geometry.Coordinates, nil
+		if strings.Contains(f.Properties.DisplayName, keyword) && len(f.Geometry.Coordinates) >= 2 {
+			features = append(features, f)
 		}
 	}
-	return coordinates, fmt.Errorf("no address associated with %s", keyword)
+	if len(features) == 0 {
+		return nil, &RequestError{Kind: FailureNotFound, Detail: fmt.Sprintf("no address associated with %s", keyword)}
+	}
+
+	sort.SliceStable(features, func(i, j int) bool {
+		return features[i].betterThan(features[j])
+	})
+	candidates := make([]Candidate, len(features))
+	for i, f := range features {
+		candidates[i] = f.candidate()
+	}
+	return candidates, nil
+}
+
+func (f *Feature) candidate() Candidate {
+	return Candidate{
+		Address:      f.Properties.DisplayName,
+		Coordinates:  coordinates.GuestCoordinates{Long: f.Geometry.Coordinates[0], Lat: f.Geometry.Coordinates[1]},
+		LocationType: f.Properties.AddressType,
+		Confidence:   f.confidence(),
+	}
+}
+
+// confidence grades a Nominatim feature by how specific a place it names.
+func (f *Feature) confidence() Confidence {
+	switch f.Properties.AddressType {
+	case "house", "building", "amenity", "place_of_worship":
+		return ConfidenceHigh
+	case "road", "street":
+		return ConfidenceMedium
+	}
+	if f.Properties.Category == "building" || f.Properties.Category == "amenity" {
+		return ConfidenceHigh
+	}
+	return ConfidenceLow
+}
+
+func (f *Feature) betterThan(other Feature) bool {
+	c, oc := f.confidence(), other.confidence()
+	if c != oc {
+		return c > oc
+	}
+	return f.Properties.Importance > other.Properties.Importance
 }
@@ -0,0 +1,83 @@
+package geoapi
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const nominatimBody = `{"features": [
+	{"properties": {"display_name": "Parkdale Avenue, Ottawa", "addresstype": "road", "importance": 0.6},
+	 "geometry": {"coordinates": [-75.73, 45.40]}},
+	{"properties": {"display_name": "555, Parkdale Avenue, Ottawa", "addresstype": "house", "importance": 0.2},
+	 "geometry": {"coordinates": [-75.72, 45.39]}},
+	{"properties": {"display_name": "555 Parkdale, Toronto", "addresstype": "house", "importance": 0.9},
+	 "geometry": {"coordinates": [-79.38, 43.65]}}
+]}`
+
+func TestParseGeocodeResponseRanksByConfidence(t *testing.T) {
+	candidates, err := parseGeocodeResponse([]byte(nominatimBody), "Ottawa")
+	if err != nil {
+		t.Fatalf("parseGeocodeResponse: %v", err)
+	}
+	if len(candidates) != 2 {
+		t.Fatalf("got %d candidates, want the 2 in Ottawa", len(candidates))
+	}
+	best := candidates[0]
+	if best.Confidence != ConfidenceHigh || best.Coordinates.Long != -75.72 || best.Coordinates.Lat != 45.39 {
+		t.Errorf("best = %+v, want the house at -75.72, 45.39", best)
+	}
+	if candidates[1].Confidence != ConfidenceMedium {
+		t.Errorf("second confidence = %v, want Medium", candidates[1].Confidence)
+	}
+}
+
+func TestParseGeocodeResponseNotFound(t *testing.T) {
+	_, err := parseGeocodeResponse([]byte(nominatimBody), "Montreal")
+	if failureKind(err) != FailureNotFound {
+		t.Errorf("err = %v, want not found", err)
+	}
+}
+
+// stubTransport answers every request with body.
+type stubTransport string
+
+func (s stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(string(s))),
+		Request:    req,
+	}, nil
+}
+
+func stubNominatim(t *testing.T, body string) {
+	t.Helper()
+	transport.set(stubTransport(body))
+	t.Cleanup(func() { transport.set(nil) })
+}
+
+func TestRetreiveAddressCoordinate(t *testing.T) {
+	stubNominatim(t, nominatimBody)
+	match, err := retreiveAddressCoordinate("555 Parkdale Ave")
+	if err != nil {
+		t.Fatalf("retreiveAddressCoordinate: %v", err)
+	}
+	if match.Confidence != ConfidenceHigh || match.Address != "555, Parkdale Avenue, Ottawa" {
+		t.Errorf("match = %+v, want the house", match)
+	}
+}
+
+func TestRetreiveAddressCoordinateLowConfidence(t *testing.T) {
+	stubNominatim(t, `{"features": [{"properties": {"display_name": "Ottawa", "addresstype": "city"},
+		"geometry": {"coordinates": [-75.70, 45.42]}}]}`)
+	_, err := retreiveAddressCoordinate("Parkdale")
+	var review *ReviewError
+	if !errors.As(err, &review) || len(review.Candidates) != 1 {
+		t.Fatalf("err = %v, want a ReviewError with the match", err)
+	}
+	if !errors.Is(err, ErrNeedsReview) {
+		t.Error("ReviewError is not ErrNeedsReview")
+	}
+}
@@ -0,0 +1,180 @@
+package geoapi
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// secretParams are query parameters removed from recorded URLs so that
+// recordings can be shared without leaking API keys.
+var secretParams = []string{"key", "api_key", "apikey", "access_token", "token", "signature"}
+
+// Recording holds the responses of the geocoding and routing services for
+// one run, in the order they were requested.
+type Recording struct {
+	Interactions []Interaction
+}
+
+// Interaction is one recorded request and its response. URL has its
+// secret parameters removed.
+type Interaction struct {
+	Method      string
+	URL         string
+	Status      int
+	ContentType string `json:",omitempty"`
+	Body        string
+}
+
+// redactURL returns u without its secret parameters, with the query in a
+// stable order.
+func redactURL(u *url.URL) string {
+	clean := *u
+	q := clean.Query()
+	for _, p := range secretParams {
+		q.Del(p)
+	}
+	clean.RawQuery = q.Encode()
+	return clean.String()
+}
+
+type recorder struct {
+	next http.RoundTripper
+	mu   sync.Mutex
+	rec  *Recording
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	r.rec.Interactions = append(r.rec.Interactions, Interaction{
+		Method:      req.Method,
+		URL:         redactURL(req.URL),
+		Status:      resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        string(body),
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// replayer answers requests from a Recording. Repeated requests for the same
+// URL get the recorded responses in order, the last one repeating once they
+// run out. Requests that were never recorded get a 404.
+type replayer struct {
+	mu   sync.Mutex
+	rec  *Recording
+	next map[string]int
+}
+
+func (r *replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := req.Method + " " + redactURL(req.URL)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := 0
+	var match *Interaction
+	for i := range r.rec.Interactions {
+		in := &r.rec.Interactions[i]
+		if in.Method+" "+in.URL != key {
+			continue
+		}
+		match = in
+		if seen == r.next[key] {
+			break
+		}
+		seen++
+	}
+	r.next[key]++
+
+	if match == nil {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Status:     "404 Not Recorded",
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewReader([]byte(fmt.Sprintf("no recorded response for %s", key)))),
+			Request:    req,
+		}, nil
+	}
+
+	header := http.Header{}
+	if match.ContentType != "" {
+		header.Set("Content-Type", match.ContentType)
+	}
+	return &http.Response{
+		StatusCode: match.Status,
+		Status:     fmt.Sprintf("%d %s", match.Status, http.StatusText(match.Status)),
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader([]byte(match.Body))),
+		Request:    req,
+	}, nil
+}
+
+// switchTransport sends requests through the network, a recorder or a
+// replayer, and can be switched between them while requests are in flight.
+type switchTransport struct {
+	mu sync.RWMutex
+	rt http.RoundTripper
+}
+
+func (s *switchTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt := s.current()
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return rt.RoundTrip(req)
+}
+
+func (s *switchTransport) current() http.RoundTripper {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rt
+}
+
+func (s *switchTransport) set(rt http.RoundTripper) {
+	s.mu.Lock()
+	s.rt = rt
+	s.mu.Unlock()
+}
+
+var transport = &switchTransport{}
+
+// isReplaying reports whether requests are answered from a Recording.
+func isReplaying() bool {
+	_, ok := transport.current().(*replayer)
+	return ok
+}
+
+// StartRecording captures every response from the geocoding and routing
+// services into the returned Recording until StopRecording is called.
+func StartRecording() *Recording {
+	rec := &Recording{}
+	transport.set(&recorder{next: http.DefaultTransport, rec: rec})
+	return rec
+}
+
+// StartReplay answers every request to the geocoding and routing services
+// from rec instead of the network, until StopRecording is called. No API key
+// is needed while replaying, and failed requests are retried without waiting.
+func StartReplay(rec *Recording) {
+	transport.set(&replayer{rec: rec, next: make(map[string]int)})
+}
+
+// StopRecording ends recording or replay and goes back to the network.
+func StopRecording() {
+	transport.set(nil)
+}
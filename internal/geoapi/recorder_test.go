@@ -0,0 +1,43 @@
+package geoapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestReplayRetriesWithoutWaiting(t *testing.T) {
+	delays := fakeSleep(t)
+	url := "https://example.com/geocode?address=1+Main+St"
+	StartReplay(&Recording{Interactions: []Interaction{
+		{Method: http.MethodGet, URL: url, Status: http.StatusServiceUnavailable},
+		{Method: http.MethodGet, URL: url, Status: http.StatusOK, Body: "ok"},
+	}})
+	t.Cleanup(StopRecording)
+
+	resp, err := sendWithRetry(newRequest(t, context.Background(), url+"&key=secret"))
+	if err != nil {
+		t.Fatalf("sendWithRetry: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "ok" {
+		t.Errorf("body = %q, want the recorded retry", body)
+	}
+	if len(*delays) != 0 {
+		t.Errorf("slept %v while replaying", *delays)
+	}
+}
+
+func TestReplayNeedsNoAPIKey(t *testing.T) {
+	StartReplay(&Recording{})
+	key, err := getApiKey()
+	StopRecording()
+	if err != nil || key != "" {
+		t.Errorf("getApiKey = %q, %v, want no key while replaying", key, err)
+	}
+	if isReplaying() {
+		t.Error("still replaying after StopRecording")
+	}
+}
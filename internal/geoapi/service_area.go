@@ -0,0 +1,112 @@
+package geoapi
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/config"
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+)
+
+type BoundingBox struct {
+	SouthWest coordinates.GuestCoordinates
+	NorthEast coordinates.GuestCoordinates
+}
+
+func (b BoundingBox) Contains(c coordinates.GuestCoordinates) bool {
+	return c.Lat >= b.SouthWest.Lat && c.Lat <= b.NorthEast.Lat &&
+		c.Long >= b.SouthWest.Long && c.Long <= b.NorthEast.Long
+}
+
+// ServiceArea is the region guests are expected to live in. Geocoding
+// requests are biased towards it and matches outside it are rejected.
+type ServiceArea struct {
+	Bounds        BoundingBox
+	Depot         coordinates.GuestCoordinates
+	MaxDistanceKm float64
+	Components    string
+	AddressSuffix string
+}
+
+var DefaultServiceArea = ServiceArea{
+	Bounds: BoundingBox{
+		SouthWest: coordinates.GuestCoordinates{Long: -76.36, Lat: 44.96},
+		NorthEast: coordinates.GuestCoordinates{Long: -75.24, Lat: 45.54},
+	},
+	Depot:         coordinates.GuestCoordinates{Long: -75.726118, Lat: 45.396826},
+	MaxDistanceKm: 40,
+	Components:    "country:CA|administrative_area:ON",
+	AddressSuffix: "Ottawa, ON, Canada",
+}
+
+var (
+	serviceAreaOnce sync.Once
+	activeArea      ServiceArea
+)
+
+// serviceArea returns the configured service area, falling back to
+// DefaultServiceArea for anything the maps config leaves out.
+func serviceArea() ServiceArea {
+	serviceAreaOnce.Do(func() {
+		activeArea = DefaultServiceArea
+
+		sa, err := config.GetEmbeddedServiceArea()
+		if err != nil || sa == nil {
+			return
+		}
+
+		if sa.South != 0 && sa.West != 0 && sa.North != 0 && sa.East != 0 {
+			activeArea.Bounds = BoundingBox{
+				SouthWest: coordinates.GuestCoordinates{Long: sa.West, Lat: sa.South},
+				NorthEast: coordinates.GuestCoordinates{Long: sa.East, Lat: sa.North},
+			}
+		}
+		if sa.DepotLat != 0 && sa.DepotLng != 0 {
+			activeArea.Depot = coordinates.GuestCoordinates{Long: sa.DepotLng, Lat: sa.DepotLat}
+		}
+		if sa.MaxDistanceKm > 0 {
+			activeArea.MaxDistanceKm = sa.MaxDistanceKm
+		}
+		if sa.Components != "" {
+			activeArea.Components = sa.Components
+		}
+		if sa.AddressSuffix != "" {
+			activeArea.AddressSuffix = sa.AddressSuffix
+		}
+	})
+	return activeArea
+}
+
+// boundsParam formats the bounds for the Geocoding API bounds parameter.
+func (sa ServiceArea) boundsParam() string {
+	sw, ne := sa.Bounds.SouthWest, sa.Bounds.NorthEast
+	return fmt.Sprintf("%f,%f|%f,%f", sw.Lat, sw.Long, ne.Lat, ne.Long)
+}
+
+// check explains why c lies outside the service area, or returns "" when it
+// is inside.
+func (sa ServiceArea) check(c coordinates.GuestCoordinates) string {
+	distance := c.DistanceKm(sa.Depot)
+	if sa.MaxDistanceKm > 0 && distance > sa.MaxDistanceKm {
+		return fmt.Sprintf("%.1f km from the depot, the limit is %.0f km", distance, sa.MaxDistanceKm)
+	}
+	if !sa.Bounds.Contains(c) {
+		return fmt.Sprintf("(%f, %f) is outside the service area bounds", c.Lat, c.Long)
+	}
+	return ""
+}
+
+// OutsideAreaError is returned when every geocoding match for an address
+// lies outside the service area.
+type OutsideAreaError struct {
+	Address string
+	Reason  string
+}
+
+func (e *OutsideAreaError) Error() string {
+	return fmt.Sprintf("outside service area: matched %s, %s", e.Address, e.Reason)
+}
+
+func (e *OutsideAreaError) Is(target error) bool {
+	return target == ErrOutsideArea
+}
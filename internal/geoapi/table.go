@@ -28,7 +28,7 @@ func (e *Event) RetreiveDistanceMatrix() error {
 	url := buildDistanceMatrixURL(&coordListURL)
 	jsonresp, err := fetchDistanceMatrix(&url)
 	if err != nil {
-		return fmt.Errorf("%v", err)
+		return err
 	}
 
 	matrix, err := parseOsrmResponse(&jsonresp)
@@ -54,18 +54,13 @@ func fetchDistanceMatrix(url *string) ([]byte, error) {
 	}
 
 	req.Header.Set("User-Agent", "outreach-routing/1.0")
-	resp, err := httpClient.Do(req)
+	resp, err := sendWithRetry(req)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("osrm server: %w", err)
 	}
 
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		fmt.Println(resp.Status)
-		return nil, fmt.Errorf("osrm server: %s", resp.Status)
-	}
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("cannot read response body: %v", err)
@@ -82,7 +77,7 @@ func parseOsrmResponse(body *[]byte) ([][]float64, error) {
 	}
 
 	if osrm.Status != "Ok" {
-		return nil, fmt.Errorf("OSRM Status: %s", osrm.Status)
+		return nil, &RequestError{Kind: FailureUnknown, Host: "router.project-osrm.org", Detail: fmt.Sprintf("OSRM Status: %s", osrm.Status)}
 	}
 
 	return osrm.Distances, nil
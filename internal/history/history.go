@@ -0,0 +1,230 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/app"
+	"go.etcd.io/bbolt"
+)
+
+var (
+	eventsBucket   = []byte("events")
+	sessionsBucket = []byte("sessions")
+)
+
+// Event summarises one routed event. Key identifies the event, so routing
+// the same worksheet again replaces its earlier entry.
+type Event struct {
+	Key        string
+	Date       time.Time
+	Updated    time.Time
+	EventType  string
+	Worksheet  string
+	Source     string
+	Guests     int
+	People     int
+	Vehicles   int
+	DistanceKm float64
+	Edits      int
+	Routes     []Route
+}
+
+// Route is one driver's stops in an event.
+type Route struct {
+	Driver     string
+	DistanceKm float64
+	Stops      []Stop
+}
+
+// Stop is one guest delivered to.
+type Stop struct {
+	ID        string `json:",omitempty"`
+	Name      string
+	Address   string
+	Phone     string
+	GroupSize int
+}
+
+// NewEvent summarises the routes of an event dated date.
+func NewEvent(key string, date time.Time, e *app.Event, lr *app.LocationRegistry, rm *app.RouteManager) Event {
+	ev := Event{
+		Key:       key,
+		Date:      date,
+		Updated:   time.Now(),
+		EventType: e.EventType,
+		Edits:     len(rm.Edits),
+	}
+
+	for i := range rm.Vehicles {
+		v := &rm.Vehicles[i]
+		if len(v.Guests) == 0 {
+			continue
+		}
+		route := Route{Driver: v.DriverName(i), DistanceKm: v.RouteDistanceKm(lr)}
+		for _, g := range v.Guests {
+			route.Stops = append(route.Stops, Stop{
+				ID:        g.ID,
+				Name:      g.Name,
+				Address:   g.Address,
+				Phone:     g.PhoneNumber,
+				GroupSize: g.GroupSize,
+			})
+			ev.Guests++
+			ev.People += g.GroupSize
+		}
+		ev.Vehicles++
+		ev.DistanceKm += route.DistanceKm
+		ev.Routes = append(ev.Routes, route)
+	}
+	return ev
+}
+
+// Baseline returns the driver each guest of the event had, for warm
+// starting the next event's routes.
+func (ev Event) Baseline() app.Baseline {
+	b := make(app.Baseline)
+	for i, r := range ev.Routes {
+		for _, s := range r.Stops {
+			b.Add(s.ID, s.Name, app.Assignment{Driver: r.Driver, Address: s.Address, Vehicle: i})
+		}
+	}
+	return b
+}
+
+// Store is the local history database, one file shared by every event.
+type Store struct {
+	db *bbolt.DB
+}
+
+// Open opens or creates the history database at path.
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("could not create history folder: %w", err)
+	}
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("could not open history database: %w", err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, name := range [][]byte{eventsBucket, sessionsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("could not prepare history database: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database. The Store must not be used afterwards.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Save stores ev and, when given, the saved session it was routed in,
+// replacing any earlier entry with the same key.
+func (s *Store) Save(ev Event, session []byte) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		if err := tx.Bucket(eventsBucket).Put([]byte(ev.Key), data); err != nil {
+			return err
+		}
+		if session == nil {
+			return nil
+		}
+		return tx.Bucket(sessionsBucket).Put([]byte(ev.Key), session)
+	})
+}
+
+// Events returns every stored event, most recent first.
+func (s *Store) Events() ([]Event, error) {
+	events := make([]Event, 0)
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(eventsBucket).ForEach(func(k, v []byte) error {
+			var ev Event
+			if err := json.Unmarshal(v, &ev); err != nil {
+				return fmt.Errorf("history entry %q: %w", k, err)
+			}
+			events = append(events, ev)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Date.After(events[j].Date)
+	})
+	return events, nil
+}
+
+// Session returns the saved session of the event with the given key.
+func (s *Store) Session(key string) ([]byte, error) {
+	var session []byte
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(sessionsBucket).Get([]byte(key))
+		if data == nil {
+			return fmt.Errorf("no saved routes for %q", key)
+		}
+		session = append([]byte(nil), data...)
+		return nil
+	})
+	return session, err
+}
+
+// Delete removes an event and its session.
+func (s *Store) Delete(key string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		if err := tx.Bucket(eventsBucket).Delete([]byte(key)); err != nil {
+			return err
+		}
+		return tx.Bucket(sessionsBucket).Delete([]byte(key))
+	})
+}
+
+// Totals adds up the events of a period. Deliveries counts every guest
+// delivered to, while Households counts each address once.
+type Totals struct {
+	Events     int
+	Deliveries int
+	Households int
+	People     int
+	DistanceKm float64
+}
+
+// Summarize totals the events dated from from up to, but not including, to.
+func Summarize(events []Event, from, to time.Time) Totals {
+	var t Totals
+	households := make(map[string]bool)
+	for _, ev := range events {
+		if ev.Date.Before(from) || !ev.Date.Before(to) {
+			continue
+		}
+		t.Events++
+		t.Deliveries += ev.Guests
+		t.People += ev.People
+		t.DistanceKm += ev.DistanceKm
+		for _, r := range ev.Routes {
+			for _, s := range r.Stops {
+				households[strings.ToLower(strings.Join(strings.Fields(s.Address), " "))] = true
+			}
+		}
+	}
+	t.Households = len(households)
+	return t
+}
@@ -0,0 +1,166 @@
+package history
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/app"
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+)
+
+// routedDinner routes a dinner of four households around the depot and
+// returns it with its session file.
+func routedDinner(t *testing.T) (*app.Event, *app.LocationRegistry, *app.RouteManager, []byte) {
+	t.Helper()
+	lr := &app.LocationRegistry{Depot: app.DefaultDepot}
+	lr.CoordianteMap.AddressOrder = []string{"depot"}
+	e := &app.Event{EventType: "Dinner"}
+	for i, n := range []int{2, 2, 1, 1} {
+		angle := float64(i) * 2.4
+		c := coordinates.GuestCoordinates{
+			Long: app.DefaultDepot.Long + 0.05*math.Cos(angle)*float64(1+i%4),
+			Lat:  app.DefaultDepot.Lat + 0.05*math.Sin(angle)*float64(1+i%4),
+		}
+		addr := fmt.Sprintf("%d Main St", i+1)
+		lr.AddLocation(addr, c, n)
+		e.Guests = append(e.Guests, app.Guest{
+			ID: fmt.Sprintf("g%d", i+1), Name: fmt.Sprintf("Guest %d", i+1),
+			GroupSize: n, Coordinates: c, Address: addr,
+		})
+	}
+	coords := lr.Coordinates()
+	lr.DistanceMatrix = make([][]float64, len(coords))
+	for i := range coords {
+		for j := range coords {
+			lr.DistanceMatrix[i] = append(lr.DistanceMatrix[i], 100000*math.Hypot(coords[i].Long-coords[j].Long, coords[i].Lat-coords[j].Lat))
+		}
+	}
+
+	rm, err := app.OrchestateDispatch(lr, e, 0)
+	if err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	var session bytes.Buffer
+	if err := app.SaveSession(&session, app.Session{Event: *e, LocationRegistry: *lr, Routes: rm}); err != nil {
+		t.Fatal(err)
+	}
+	return e, lr, rm, session.Bytes()
+}
+
+func openStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "history", "events.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestStoreKeepsEventsInDateOrder(t *testing.T) {
+	s := openStore(t)
+	e, lr, rm, session := routedDinner(t)
+	june := time.Date(2026, 6, 12, 0, 0, 0, 0, time.UTC)
+	july := time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC)
+
+	if err := s.Save(NewEvent("july", july, e, lr, rm), session); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(NewEvent("june", june, e, lr, rm), nil); err != nil {
+		t.Fatal(err)
+	}
+	events, err := s.Events()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].Key != "july" || events[1].Key != "june" {
+		t.Fatalf("events = %v, want july then june", events)
+	}
+	ev := events[1]
+	if !ev.Date.Equal(june) || ev.EventType != "Dinner" || ev.Guests != 4 || ev.People != 6 || len(ev.Routes) != ev.Vehicles {
+		t.Errorf("june = %+v", ev)
+	}
+	if ev.DistanceKm <= 0 {
+		t.Errorf("june drove %.1f km", ev.DistanceKm)
+	}
+
+	// Routing the same worksheet again replaces its entry.
+	if err := s.Save(NewEvent("june", june.AddDate(0, 0, 1), e, lr, rm), nil); err != nil {
+		t.Fatal(err)
+	}
+	if events, err = s.Events(); err != nil || len(events) != 2 || events[1].Date.Day() != 13 {
+		t.Errorf("after saving june again: %v, %v", events, err)
+	}
+
+	if _, err := s.Session("june"); err == nil {
+		t.Error("june has a session although none was saved")
+	}
+	if err := s.Delete("july"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Session("july"); err == nil {
+		t.Error("deleting july kept its session")
+	}
+	if events, _ = s.Events(); len(events) != 1 {
+		t.Errorf("after deleting july: %v", events)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	e, lr, rm, _ := routedDinner(t)
+	june := NewEvent("june", time.Date(2026, 6, 12, 0, 0, 0, 0, time.UTC), e, lr, rm)
+	july := NewEvent("july", time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC), e, lr, rm)
+	july.Routes[0].Stops[0].Address = "  " + july.Routes[0].Stops[0].Address + " "
+	events := []Event{july, june}
+
+	got := Summarize(events, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC))
+	want := Totals{Events: 2, Deliveries: 8, Households: 4, People: 12, DistanceKm: june.DistanceKm + july.DistanceKm}
+	if got != want {
+		t.Errorf("June and July = %+v, want %+v", got, want)
+	}
+
+	// The end of the period is left out.
+	got = Summarize(events, june.Date, july.Date)
+	want = Totals{Events: 1, Deliveries: 4, Households: 4, People: 6, DistanceKm: june.DistanceKm}
+	if got != want {
+		t.Errorf("June = %+v, want %+v", got, want)
+	}
+}
+
+func TestBaselineOfStoredSession(t *testing.T) {
+	s := openStore(t)
+	e, lr, rm, session := routedDinner(t)
+	if err := s.Save(NewEvent("june", time.Now(), e, lr, rm), session); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := s.Session("june")
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved, err := app.LoadSession(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err := s.Events()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fromSession := saved.Routes.Assignments()
+	fromEvent := events[0].Baseline()
+	for _, g := range e.Guests {
+		want := rm.Assignments()[app.BaselineKeys(g.ID, g.Name)[0]]
+		for source, b := range map[string]app.Baseline{"session": fromSession, "event": fromEvent} {
+			for _, key := range app.BaselineKeys(g.ID, g.Name) {
+				if got := b[key]; got.Driver != want.Driver || got.Address != g.Address {
+					t.Errorf("%s baseline has %s with %s at %q, want %s", source, key, got.Driver, got.Address, want.Driver)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package notify
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Message is one notification. To is an email address or a phone number
+// depending on the Notifier it is sent through.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier delivers messages over one channel.
+type Notifier interface {
+	Name() string
+	Send(msg Message) error
+}
+
+// LogNotifier writes messages to a logger instead of sending them, for
+// trying the workflow without an email or SMS account.
+type LogNotifier struct {
+	Logger *log.Logger
+}
+
+func (n *LogNotifier) Name() string {
+	return "Log only"
+}
+
+func (n *LogNotifier) Send(msg Message) error {
+	if strings.TrimSpace(msg.To) == "" {
+		return fmt.Errorf("no recipient")
+	}
+	n.Logger.Printf("notification to %s: %s\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
@@ -0,0 +1,85 @@
+package notify
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var httpClient = &http.Client{Timeout: 20 * time.Second}
+
+// HTTPSMSNotifier posts messages to an SMS gateway using Twilio's form
+// fields (To, From, Body) and basic authentication. For Twilio the URL is
+// https://api.twilio.com/2010-04-01/Accounts/<AccountID>/Messages.json;
+// any gateway or local stub accepting the same request works as well.
+type HTTPSMSNotifier struct {
+	URL       string
+	AccountID string
+	AuthToken string
+	From      string
+}
+
+func (n *HTTPSMSNotifier) Name() string {
+	return "SMS"
+}
+
+func (n *HTTPSMSNotifier) Send(msg Message) error {
+	if n.URL == "" {
+		return fmt.Errorf("SMS is not configured: gateway URL is required")
+	}
+	to := normalizePhone(msg.To)
+	if to == "" {
+		return fmt.Errorf("%q is not a phone number", msg.To)
+	}
+
+	form := url.Values{}
+	form.Set("To", to)
+	form.Set("From", n.From)
+	form.Set("Body", msg.Body)
+
+	req, err := http.NewRequest(http.MethodPost, n.URL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("invalid SMS gateway URL: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if n.AccountID != "" {
+		req.SetBasicAuth(n.AccountID, n.AuthToken)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not reach SMS gateway: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("SMS gateway returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// normalizePhone reduces a phone number as typed in the sheet to E.164,
+// assuming North American numbers when no country code is given.
+func normalizePhone(phone string) string {
+	digits := make([]rune, 0, len(phone))
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, r)
+		}
+	}
+
+	switch {
+	case len(digits) == 10:
+		return "+1" + string(digits)
+	case len(digits) == 11 && digits[0] == '1':
+		return "+" + string(digits)
+	case len(digits) > 11 && strings.HasPrefix(strings.TrimSpace(phone), "+"):
+		return "+" + string(digits)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,74 @@
+package notify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPSMSNotifierSend(t *testing.T) {
+	var got *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		got = r
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	n := &HTTPSMSNotifier{URL: srv.URL, AccountID: "AC1", AuthToken: "secret", From: "+16135550100"}
+	if err := n.Send(Message{To: "(613) 555-0199", Body: "Your ride is on the way"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if got.Method != http.MethodPost {
+		t.Errorf("method = %s, want POST", got.Method)
+	}
+	if user, pass, ok := got.BasicAuth(); !ok || user != "AC1" || pass != "secret" {
+		t.Errorf("basic auth = %q, %q, %v", user, pass, ok)
+	}
+	for field, want := range map[string]string{"To": "+16135550199", "From": "+16135550100", "Body": "Your ride is on the way"} {
+		if v := got.PostForm.Get(field); v != want {
+			t.Errorf("%s = %q, want %q", field, v, want)
+		}
+	}
+}
+
+func TestHTTPSMSNotifierGatewayError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid From number", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	n := &HTTPSMSNotifier{URL: srv.URL}
+	err := n.Send(Message{To: "613-555-0199", Body: "hi"})
+	if err == nil || !strings.Contains(err.Error(), "invalid From number") {
+		t.Errorf("err = %v, want the gateway's message", err)
+	}
+}
+
+func TestHTTPSMSNotifierRejectsBadInput(t *testing.T) {
+	if err := (&HTTPSMSNotifier{}).Send(Message{To: "613-555-0199"}); err == nil {
+		t.Error("sent without a gateway URL")
+	}
+	if err := (&HTTPSMSNotifier{URL: "http://127.0.0.1:1"}).Send(Message{To: "555-0199"}); err == nil {
+		t.Error("sent to an incomplete phone number")
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := map[string]string{
+		"613-555-0199":     "+16135550199",
+		"1 (613) 555 0199": "+16135550199",
+		"+44 20 7946 0958": "+442079460958",
+		"555-0199":         "",
+		"":                 "",
+	}
+	for in, want := range tests {
+		if got := normalizePhone(in); got != want {
+			t.Errorf("normalizePhone(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
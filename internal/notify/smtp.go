@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPNotifier sends messages as plain-text email. Leaving Username empty
+// sends without authentication, which is what local test servers such as
+// MailHog expect.
+type SMTPNotifier struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+func (n *SMTPNotifier) Name() string {
+	return "Email"
+}
+
+func (n *SMTPNotifier) Send(msg Message) error {
+	if n.Host == "" || n.From == "" {
+		return fmt.Errorf("email is not configured: host and from address are required")
+	}
+	to := strings.TrimSpace(msg.To)
+	if !strings.Contains(to, "@") {
+		return fmt.Errorf("%q is not an email address", msg.To)
+	}
+
+	var auth smtp.Auth
+	if n.Username != "" {
+		auth = smtp.PlainAuth("", n.Username, n.Password, n.Host)
+	}
+
+	addr := net.JoinHostPort(n.Host, fmt.Sprint(n.port()))
+	if err := smtp.SendMail(addr, auth, n.From, []string{to}, n.format(to, msg)); err != nil {
+		return fmt.Errorf("could not send email to %s: %w", to, err)
+	}
+	return nil
+}
+
+func (n *SMTPNotifier) port() int {
+	if n.Port == 0 {
+		return 587
+	}
+	return n.Port
+}
+
+func (n *SMTPNotifier) format(to string, msg Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", n.From)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
@@ -0,0 +1,124 @@
+package notify
+
+import (
+	"bufio"
+	"encoding/base64"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// smtpSession is what the fake server received.
+type smtpSession struct {
+	auth string
+	from string
+	to   []string
+	data string
+}
+
+// fakeSMTP accepts one message on a local port, advertising AUTH PLAIN,
+// and returns the host, port and a channel that receives the session.
+func fakeSMTP(t *testing.T) (string, int, <-chan smtpSession) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	done := make(chan smtpSession, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var s smtpSession
+		r := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			cmd := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"):
+				reply("250-localhost")
+				reply("250 AUTH PLAIN")
+			case strings.HasPrefix(cmd, "AUTH PLAIN"):
+				decoded, _ := base64.StdEncoding.DecodeString(strings.TrimSpace(line[len("AUTH PLAIN"):]))
+				s.auth = string(decoded)
+				reply("235 Authenticated")
+			case strings.HasPrefix(cmd, "MAIL FROM:"):
+				s.from = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+				reply("250 OK")
+			case strings.HasPrefix(cmd, "RCPT TO:"):
+				s.to = append(s.to, strings.Trim(line[len("RCPT TO:"):], "<> "))
+				reply("250 OK")
+			case cmd == "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				s.data = data.String()
+				reply("250 OK")
+			case cmd == "QUIT":
+				reply("221 Bye")
+				done <- s
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return host, p, done
+}
+
+func TestSMTPNotifierSend(t *testing.T) {
+	host, port, done := fakeSMTP(t)
+	n := &SMTPNotifier{Host: host, Port: port, Username: "outreach", Password: "secret", From: "routes@example.com"}
+
+	err := n.Send(Message{To: " mina@example.com ", Subject: "Your route", Body: "Stop 1\nStop 2"})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	s := <-done
+	if s.auth != "\x00outreach\x00secret" {
+		t.Errorf("auth = %q, want the username and password", s.auth)
+	}
+	if s.from != "routes@example.com" || len(s.to) != 1 || s.to[0] != "mina@example.com" {
+		t.Errorf("envelope = %q -> %q", s.from, s.to)
+	}
+	for _, want := range []string{"Subject: Your route\r\n", "To: mina@example.com\r\n", "\r\n\r\nStop 1\r\nStop 2"} {
+		if !strings.Contains(s.data, want) {
+			t.Errorf("message lacks %q:\n%s", want, s.data)
+		}
+	}
+}
+
+func TestSMTPNotifierRejectsBadInput(t *testing.T) {
+	if err := (&SMTPNotifier{}).Send(Message{To: "mina@example.com"}); err == nil {
+		t.Error("sent without a host")
+	}
+	n := &SMTPNotifier{Host: "127.0.0.1", Port: 1, From: "routes@example.com"}
+	if err := n.Send(Message{To: "613-555-0199"}); err == nil {
+		t.Error("sent to a phone number")
+	}
+}
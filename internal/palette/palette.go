@@ -0,0 +1,42 @@
+package palette
+
+import (
+	"fmt"
+	"image/color"
+)
+
+// Depot is the colour name used for the depot marker.
+const Depot = "brown"
+
+// Colors maps colour names to the colours used on the map and in exports.
+var Colors = map[string]color.NRGBA{
+	"brown":   {139, 69, 19, 255},
+	"red":     {255, 0, 0, 255},
+	"blue":    {0, 0, 255, 255},
+	"green":   {0, 128, 0, 255},
+	"orange":  {255, 165, 0, 255},
+	"purple":  {128, 0, 128, 255},
+	"cyan":    {0, 255, 255, 255},
+	"magenta": {255, 0, 255, 255},
+	"pink":    {255, 192, 203, 255},
+	"teal":    {0, 128, 128, 255},
+	"indigo":  {75, 0, 130, 255},
+	"gold":    {255, 215, 0, 255},
+	"lime":    {50, 205, 50, 255},
+}
+
+// VehicleColors are assigned to vehicles in order, repeating when there are
+// more vehicles than colours.
+var VehicleColors = []string{"red", "blue", "green", "orange", "purple", "cyan",
+	"magenta", "pink", "teal", "indigo", "gold", "lime"}
+
+// Vehicle returns the colour name of the vehicle at index.
+func Vehicle(index int) string {
+	return VehicleColors[index%len(VehicleColors)]
+}
+
+// Hex formats a named colour as "#rrggbb".
+func Hex(name string) string {
+	c := Colors[name]
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
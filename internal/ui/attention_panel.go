@@ -0,0 +1,219 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+	"github.com/andrew-tawfik/outreach-routing/internal/geoapi"
+)
+
+// attentionRow holds the coordinator's edits for one guest that could not be
+// geocoded.
+type attentionRow struct {
+	index      int
+	failed     geoapi.FailedGuest
+	candidates []geoapi.Candidate
+
+	include         *widget.Check
+	addressEntry    *widget.Entry
+	candidateSelect *widget.Select
+	pinEntry        *widget.Entry
+}
+
+// NewAttentionPanel lists the guests that were excluded from routing and lets
+// the coordinator correct them. onResolved is called on the UI thread after
+// the corrected guests were inserted into the routes.
+func NewAttentionPanel(cfg *Config, onResolved func()) fyne.CanvasObject {
+	if cfg.Rp == nil || !cfg.Rp.ae.ApiErrors.HasErrors() {
+		return container.NewCenter(widget.NewLabel("All guests were located successfully"))
+	}
+
+	failed := cfg.Rp.ae.ApiErrors.FailedGuests
+	rows := make([]*attentionRow, 0, len(failed))
+	cards := container.NewVBox()
+
+	for i, fg := range failed {
+		row := newAttentionRow(i, fg)
+		rows = append(rows, row)
+		cards.Add(row.card())
+	}
+
+	rerunButton := widget.NewButton("Re-run geocoding for selected guests", nil)
+	rerunButton.Importance = widget.HighImportance
+	rerunButton.OnTapped = func() {
+		resolutions := make([]GuestResolution, 0, len(rows))
+		for _, row := range rows {
+			if !row.include.Checked {
+				continue
+			}
+			res, err := row.resolution()
+			if err != nil {
+				ShowErrorNotification(cfg.MainWindow, "Invalid entry", err.Error())
+				return
+			}
+			resolutions = append(resolutions, res)
+		}
+		if len(resolutions) == 0 {
+			ShowErrorNotification(cfg.MainWindow, "Nothing selected", "Tick the guests you corrected first")
+			return
+		}
+
+		popup := ShowMessage(cfg.MainWindow)
+		popup.Show()
+		go func() {
+			err := cfg.Rp.ResolveGuests(resolutions)
+			fyne.Do(func() {
+				popup.Hide()
+				if err != nil {
+					ShowErrorNotification(cfg.MainWindow, "Processing Error", err.Error())
+					return
+				}
+				cfg.checkRoutes("Resolve Guests")
+				onResolved()
+			})
+		}()
+	}
+
+	header := widget.NewLabel(fmt.Sprintf("%d guests need attention. Correct the address, pick a match or enter "+
+		"the coordinates of a pin, tick the guest, then re-run geocoding. Low confidence matches are "+
+		"preselected and only need to be confirmed.", len(failed)))
+	header.Wrapping = fyne.TextWrapWord
+
+	return container.NewBorder(
+		header,
+		container.NewHBox(layout.NewSpacer(), rerunButton),
+		nil,
+		nil,
+		container.NewScroll(cards),
+	)
+}
+
+func newAttentionRow(index int, fg geoapi.FailedGuest) *attentionRow {
+	row := &attentionRow{index: index, failed: fg}
+
+	row.include = widget.NewCheck("Resolve", nil)
+
+	row.addressEntry = widget.NewEntry()
+	row.addressEntry.SetText(fg.Address)
+	row.addressEntry.OnChanged = func(string) {
+		row.include.SetChecked(true)
+	}
+
+	row.candidateSelect = widget.NewSelect(nil, nil)
+	row.candidateSelect.PlaceHolder = "Search to list matches"
+	if len(fg.Candidates) > 0 {
+		row.setCandidates(fg.Candidates)
+		row.candidateSelect.SetSelectedIndex(0)
+	}
+	row.candidateSelect.OnChanged = func(string) {
+		row.include.SetChecked(true)
+	}
+
+	row.pinEntry = widget.NewEntry()
+	row.pinEntry.SetPlaceHolder("45.4215, -75.6972")
+	row.pinEntry.OnChanged = func(string) {
+		row.include.SetChecked(true)
+	}
+
+	return row
+}
+
+func (row *attentionRow) card() fyne.CanvasObject {
+	searchButton := widget.NewButton("Search", nil)
+	searchButton.OnTapped = func() {
+		address := row.addressEntry.Text
+		searchButton.Disable()
+		go func() {
+			candidates, err := geoapi.GeocodeCandidates(address)
+			fyne.Do(func() {
+				searchButton.Enable()
+				if err != nil {
+					row.candidates = nil
+					row.candidateSelect.Options = nil
+					row.candidateSelect.PlaceHolder = "No matches: " + err.Error()
+					row.candidateSelect.ClearSelected()
+					row.candidateSelect.Refresh()
+					return
+				}
+				row.setCandidates(candidates)
+			})
+		}()
+	}
+
+	form := widget.NewForm(
+		widget.NewFormItem("Address", container.NewBorder(nil, nil, nil, searchButton, row.addressEntry)),
+		widget.NewFormItem("Matches", row.candidateSelect),
+		widget.NewFormItem("Pin (lat, lng)", row.pinEntry),
+	)
+
+	title := row.failed.Name
+	if row.failed.Guest.GroupSize > 1 {
+		title = fmt.Sprintf("%s (Group of %d)", title, row.failed.Guest.GroupSize)
+	}
+
+	return widget.NewCard(title, row.failed.Reason, container.NewBorder(nil, nil, nil, row.include, form))
+}
+
+func (row *attentionRow) setCandidates(candidates []geoapi.Candidate) {
+	row.candidates = candidates
+	options := make([]string, len(candidates))
+	for i, c := range candidates {
+		options[i] = fmt.Sprintf("%s (%s)", c.Address, c.Describe())
+	}
+	row.candidateSelect.Options = options
+	row.candidateSelect.PlaceHolder = fmt.Sprintf("%d matches", len(candidates))
+	row.candidateSelect.ClearSelected()
+	row.candidateSelect.Refresh()
+}
+
+// resolution turns the row's edits into a GuestResolution. A pin takes
+// precedence over a selected match, which takes precedence over the edited
+// address.
+func (row *attentionRow) resolution() (GuestResolution, error) {
+	res := GuestResolution{Index: row.index, Address: strings.TrimSpace(row.addressEntry.Text)}
+
+	if pin := strings.TrimSpace(row.pinEntry.Text); pin != "" {
+		coord, err := parsePin(pin)
+		if err != nil {
+			return res, fmt.Errorf("%s: %v", row.failed.Name, err)
+		}
+		res.Coordinates = &coord
+		return res, nil
+	}
+
+	if i := row.candidateSelect.SelectedIndex(); i >= 0 && i < len(row.candidates) {
+		c := row.candidates[i]
+		res.Address = c.Address
+		res.Coordinates = &c.Coordinates
+		return res, nil
+	}
+
+	if res.Address == "" {
+		return res, fmt.Errorf("%s: address is empty", row.failed.Name)
+	}
+	return res, nil
+}
+
+func parsePin(text string) (coordinates.GuestCoordinates, error) {
+	parts := strings.Split(text, ",")
+	if len(parts) != 2 {
+		return coordinates.GuestCoordinates{}, fmt.Errorf("pin must be written as 'lat, lng'")
+	}
+
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return coordinates.GuestCoordinates{}, fmt.Errorf("invalid latitude %q", parts[0])
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil || lng < -180 || lng > 180 {
+		return coordinates.GuestCoordinates{}, fmt.Errorf("invalid longitude %q", parts[1])
+	}
+
+	return coordinates.GuestCoordinates{Long: lng, Lat: lat}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"log"
 
 	"fyne.io/fyne/v2"
+	"github.com/andrew-tawfik/outreach-routing/internal/history"
 )
 
 type Config struct {
@@ -14,4 +15,12 @@ type Config struct {
 	Rp              *RoutingProcess
 	VehicleSection  *fyne.Container
 	GuestContainers []*fyne.Container
+	History         *history.Store
+
+	refreshOutput  func()
+	showResult     func(*RoutingProcess)
+	refreshHistory func()
+
+	// secrets holds the SMTP password and SMS token for this session only.
+	secrets map[string]string
 }
@@ -0,0 +1,199 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/andrew-tawfik/outreach-routing/internal/app"
+	"github.com/andrew-tawfik/outreach-routing/internal/notify"
+)
+
+// guestMessageRow is one guest in the arrival text dialog.
+type guestMessageRow struct {
+	vehicle int
+	guest   int
+	send    *widget.Check
+	preview *widget.Entry
+	status  *widget.Label
+}
+
+// guestMessage builds the arrival text for a guest of the vehicle at index.
+func (rp *RoutingProcess) guestMessage(index int, g app.Guest, arrival time.Time, window time.Duration) string {
+	delivery := "your groceries"
+	if rp.ae.EventType == "Dinner" {
+		delivery = "your dinner"
+	}
+
+	msg := fmt.Sprintf("Hi %s, %s will arrive between %s and %s", firstName(g.Name), delivery,
+		arrival.Format("3:04"), arrival.Add(window).Format("3:04 PM"))
+	if driver := rp.rm.Vehicles[index].Driver; driver != "" {
+		msg += " with driver " + driver
+	}
+	return msg + "."
+}
+
+func firstName(name string) string {
+	fields := strings.Fields(name)
+	if len(fields) == 0 {
+		return "there"
+	}
+	return fields[0]
+}
+
+// parseDeparture reads an "HH:MM" departure time on the day of now.
+func parseDeparture(text string, now time.Time) (time.Time, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(text))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("departure must be a time like 17:30")
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location()), nil
+}
+
+// etaOptions reads the departure, speed and stop time fields of the dialog.
+func etaOptions(departure, speed, dwell string) (app.ETAOptions, error) {
+	start, err := parseDeparture(departure, time.Now())
+	if err != nil {
+		return app.ETAOptions{}, err
+	}
+	opts := app.DefaultETAOptions(start)
+
+	kmh, err := strconv.ParseFloat(strings.TrimSpace(speed), 64)
+	if err != nil || kmh <= 0 {
+		return app.ETAOptions{}, fmt.Errorf("average speed must be a positive number")
+	}
+	minutes, err := strconv.Atoi(strings.TrimSpace(dwell))
+	if err != nil || minutes < 0 {
+		return app.ETAOptions{}, fmt.Errorf("minutes per stop must be a whole number")
+	}
+
+	opts.SpeedKmh = kmh
+	opts.Dwell = time.Duration(minutes) * time.Minute
+	return opts, nil
+}
+
+// showGuestNotifications previews an arrival text for every guest with a
+// phone number and sends the selected ones by SMS. Guests who opted out in
+// the sheet are listed but cannot be selected.
+func (cfg *Config) showGuestNotifications() {
+	rp, ok := cfg.exportRoutes()
+	if !ok {
+		return
+	}
+	prefs := cfg.App.Preferences()
+
+	departure := widget.NewEntry()
+	departure.SetText(prefs.StringWithFallback(prefETADeparture, "17:00"))
+	speed := widget.NewEntry()
+	speed.SetText(prefs.StringWithFallback(prefETASpeed, "35"))
+	dwell := widget.NewEntry()
+	dwell.SetText(prefs.StringWithFallback(prefETADwell, "5"))
+
+	rows := make([]*guestMessageRow, 0)
+	list := container.NewVBox()
+
+	for vi := range rp.rm.Vehicles {
+		v := &rp.rm.Vehicles[vi]
+		if len(v.Guests) == 0 {
+			continue
+		}
+		title := widget.NewLabelWithStyle(v.DriverName(vi), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+		list.Add(title)
+
+		for gi, g := range v.Guests {
+			row := &guestMessageRow{
+				vehicle: vi,
+				guest:   gi,
+				send:    widget.NewCheck(g.Name, nil),
+				preview: widget.NewMultiLineEntry(),
+				status:  widget.NewLabel(""),
+			}
+			row.preview.Wrapping = fyne.TextWrapWord
+			row.preview.SetMinRowsVisible(2)
+
+			switch {
+			case g.OptedOut():
+				row.send.Disable()
+				row.preview.Disable()
+				row.status.SetText("Opted out")
+			case g.PhoneNumber == "":
+				row.send.Disable()
+				row.preview.Disable()
+				row.status.SetText("No phone")
+			default:
+				row.send.SetChecked(true)
+				row.status.SetText(g.PhoneNumber)
+			}
+
+			list.Add(container.NewBorder(nil, nil, row.send, row.status))
+			list.Add(row.preview)
+			rows = append(rows, row)
+		}
+		list.Add(widget.NewSeparator())
+	}
+
+	update := func() {
+		opts, err := etaOptions(departure.Text, speed.Text, dwell.Text)
+		if err != nil {
+			ShowErrorNotification(cfg.MainWindow, "Arrival Times", err.Error())
+			return
+		}
+		prefs.SetString(prefETADeparture, strings.TrimSpace(departure.Text))
+		prefs.SetString(prefETASpeed, strings.TrimSpace(speed.Text))
+		prefs.SetString(prefETADwell, strings.TrimSpace(dwell.Text))
+
+		arrivals := make(map[int][]time.Time)
+		for _, row := range rows {
+			if _, done := arrivals[row.vehicle]; !done {
+				arrivals[row.vehicle] = rp.rm.Vehicles[row.vehicle].GuestArrivals(rp.lr, opts)
+			}
+			g := rp.rm.Vehicles[row.vehicle].Guests[row.guest]
+			row.preview.SetText(rp.guestMessage(row.vehicle, g, arrivals[row.vehicle][row.guest], opts.Window))
+		}
+	}
+	update()
+
+	var sendButton *widget.Button
+	sendButton = widget.NewButton("Send Selected", func() {
+		messages := make([]notify.Message, 0, len(rows))
+		statuses := make([]*widget.Label, 0, len(rows))
+		for _, row := range rows {
+			if !row.send.Checked || row.send.Disabled() {
+				continue
+			}
+			g := rp.rm.Vehicles[row.vehicle].Guests[row.guest]
+			messages = append(messages, notify.Message{To: g.PhoneNumber, Body: row.preview.Text})
+			statuses = append(statuses, row.status)
+		}
+		if len(messages) == 0 {
+			ShowErrorNotification(cfg.MainWindow, "Arrival Texts", "No guests are selected.")
+			return
+		}
+
+		sendButton.Disable()
+		cfg.withCredentials(cfg.guestChannel(), func() {
+			cfg.sendMessages(cfg.guestNotifier(), "guest", messages, statuses, sendButton.Enable)
+		}, sendButton.Enable)
+	})
+	sendButton.Importance = widget.HighImportance
+
+	updateButton := widget.NewButton("Update Times", update)
+
+	form := widget.NewForm(
+		widget.NewFormItem("Departure", departure),
+		widget.NewFormItem("Average speed (km/h)", speed),
+		widget.NewFormItem("Minutes per stop", dwell),
+	)
+	top := container.NewVBox(form, updateButton, widget.NewSeparator())
+
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(700, 420))
+
+	content := container.NewBorder(top, container.NewHBox(sendButton), nil, nil, scroll)
+	dialog.NewCustom("Send Guest Arrival Texts", "Close", content, cfg.MainWindow).Show()
+}
@@ -50,18 +50,28 @@ func (gw *GuestWidget) CreateRenderer() fyne.WidgetRenderer {
 	
 
 	name := gw.guest.Name
-	if len(name) > 23 {
-		name = name[:20] + "..."
+	change, changeLabel := gw.grid.routeManager.GuestChange(*gw.guest, gw.vehicleIndex)
+	maxName := 23
+	if change != app.Unchanged {
+		maxName = 14
 	}
-	nameAndGroup := fmt.Sprintf("%s (%d)", name, gw.guest.GroupSize)
+	name = truncateName(name, maxName)
+	nameAndGroup := fmt.Sprintf("%s (%s)", name, gw.guest.SizeLabel())
 	nameLabel := widget.NewLabel(nameAndGroup)
 	nameLabel.TextStyle = fyne.TextStyle{Bold: false}
 	nameLabel.TextStyle.Monospace = false
 
+	var label fyne.CanvasObject = nameLabel
+	if change != app.Unchanged {
+		gw.background.FillColor = changeColor(change)
+		changeText := widget.NewLabelWithStyle(changeLabel, fyne.TextAlignTrailing, fyne.TextStyle{Italic: true})
+		label = container.NewBorder(nil, nil, nil, changeText, nameLabel)
+	}
+
 	
 	gw.content = container.NewMax(
 		gw.background,
-		container.NewPadded(nameLabel),
+		container.NewPadded(label),
 	)
 
 	return &guestWidgetRenderer{
@@ -146,3 +156,24 @@ func (gw *GuestWidget) GetPosition() VehiclePosition {
 		TileIndex:    gw.tileIndex,
 	}
 }
+
+// changeColor tints guests that are new this week blue and guests whose
+// driver or address changed amber.
+func changeColor(change app.Change) color.Color {
+	switch change {
+	case app.ChangeNew:
+		return color.NRGBA{40, 70, 110, 255}
+	case app.ChangeAddress, app.ChangeDriver:
+		return color.NRGBA{110, 80, 30, 255}
+	}
+	return color.NRGBA{60, 60, 70, 255}
+}
+
+// truncateName shortens name to at most max characters, ending in "...".
+func truncateName(name string, max int) string {
+	r := []rune(name)
+	if len(r) <= max {
+		return name
+	}
+	return string(r[:max-3]) + "..."
+}
@@ -0,0 +1,25 @@
+package ui
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncateName(t *testing.T) {
+	tests := []struct {
+		name string
+		max  int
+		want string
+	}{
+		{"Mina Tawfik", 14, "Mina Tawfik"},
+		{"Abanoub Mikhail Girgis", 14, "Abanoub Mik..."},
+		{"Zoë Ćwikła-Łukasiewicz", 14, "Zoë Ćwikła-..."},
+		{"李小龍李小龍李小龍李小龍李小龍", 14, "李小龍李小龍李小龍李小..."},
+	}
+	for _, tt := range tests {
+		got := truncateName(tt.name, tt.max)
+		if got != tt.want || !utf8.ValidString(got) {
+			t.Errorf("truncateName(%q, %d) = %q, want %q", tt.name, tt.max, got, tt.want)
+		}
+	}
+}
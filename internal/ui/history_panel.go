@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/andrew-tawfik/outreach-routing/internal/app"
+	"github.com/andrew-tawfik/outreach-routing/internal/database"
+	"github.com/andrew-tawfik/outreach-routing/internal/history"
+)
+
+const (
+	periodThisQuarter = "This quarter"
+	periodLastQuarter = "Last quarter"
+	periodThisYear    = "This year"
+	periodAll         = "All time"
+)
+
+// periodRange returns the start and end of a named reporting period.
+func periodRange(period string, now time.Time) (time.Time, time.Time) {
+	quarter := time.Date(now.Year(), now.Month()-(now.Month()-1)%3, 1, 0, 0, 0, 0, now.Location())
+	switch period {
+	case periodThisQuarter:
+		return quarter, quarter.AddDate(0, 3, 0)
+	case periodLastQuarter:
+		return quarter.AddDate(0, -3, 0), quarter
+	case periodThisYear:
+		year := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
+		return year, year.AddDate(1, 0, 0)
+	default:
+		return time.Time{}, time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// historyKey identifies an event across runs by its guest list and tab.
+func (rp *RoutingProcess) historyKey() string {
+	return sourceKey(rp.source) + "|" + rp.worksheet
+}
+
+// recordHistory stores the current routes of rp in the history database.
+// It is called after a routing run and when edits are submitted, not when
+// saved routes are opened. Replayed events, which have no source, are not
+// recorded.
+func (cfg *Config) recordHistory(rp *RoutingProcess) {
+	if cfg.History == nil || rp == nil || rp.source == "" {
+		return
+	}
+
+	date, ok := database.WorksheetDate(rp.worksheet)
+	if !ok {
+		now := time.Now()
+		date = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	}
+
+	var session bytes.Buffer
+	if err := app.SaveSession(&session, rp.session()); err != nil {
+		cfg.ErrorLog.Printf("history: %v", err)
+		return
+	}
+
+	ev := history.NewEvent(rp.historyKey(), date, rp.ae, rp.lr, rp.rm)
+	ev.Worksheet = rp.worksheet
+	ev.Source = rp.source
+	if err := cfg.History.Save(ev, session.Bytes()); err != nil {
+		cfg.ErrorLog.Printf("history: %v", err)
+		return
+	}
+	if cfg.refreshHistory != nil {
+		cfg.refreshHistory()
+	}
+}
+
+func historyLine(ev history.Event) string {
+	title := ev.Worksheet
+	if title == "" {
+		title = ev.EventType
+	}
+	return fmt.Sprintf("%s  %s — %d guests (%d people), %d vehicles, %.1f km, %d manual edits",
+		ev.Date.Format("2006-01-02"), title, ev.Guests, ev.People, ev.Vehicles, ev.DistanceKm, ev.Edits)
+}
+
+// NewHistoryPanel lists the events routed so far with totals for a chosen
+// period. onOpen is called with the stored routes of an event the
+// coordinator opens.
+func NewHistoryPanel(cfg *Config, onOpen func(*RoutingProcess)) fyne.CanvasObject {
+	if cfg.History == nil {
+		return container.NewCenter(widget.NewLabel("The history database could not be opened"))
+	}
+
+	events, err := cfg.History.Events()
+	if err != nil {
+		cfg.ErrorLog.Printf("history: %v", err)
+		return container.NewCenter(widget.NewLabel("Could not read the history: " + err.Error()))
+	}
+	if len(events) == 0 {
+		return container.NewCenter(widget.NewLabel("Routed events will be listed here"))
+	}
+
+	totals := widget.NewLabel("")
+	periodSelect := widget.NewSelect([]string{periodThisQuarter, periodLastQuarter, periodThisYear, periodAll}, func(period string) {
+		from, to := periodRange(period, time.Now())
+		t := history.Summarize(events, from, to)
+		totals.SetText(fmt.Sprintf("%d events, %d deliveries to %d households, %d people served, %.0f km driven",
+			t.Events, t.Deliveries, t.Households, t.People, t.DistanceKm))
+	})
+	periodSelect.SetSelected(periodThisQuarter)
+
+	selected := -1
+	list := widget.NewList(
+		func() int { return len(events) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(i widget.ListItemID, o fyne.CanvasObject) {
+			o.(*widget.Label).SetText(historyLine(events[i]))
+		},
+	)
+
+	openButton := widget.NewButton("Open Routes", func() {
+		if selected < 0 {
+			return
+		}
+		data, err := cfg.History.Session(events[selected].Key)
+		if err == nil {
+			var s app.Session
+			if s, err = app.LoadSession(bytes.NewReader(data)); err == nil {
+				onOpen(sessionProcess(s))
+				return
+			}
+		}
+		ShowErrorNotification(cfg.MainWindow, "Open Routes", err.Error())
+	})
+	openButton.Importance = widget.HighImportance
+	openButton.Disable()
+
+	deleteButton := widget.NewButton("Delete", func() {
+		if selected < 0 {
+			return
+		}
+		ev := events[selected]
+		dialog.ShowConfirm("Delete Event", fmt.Sprintf("Remove %s from the history?", historyLine(ev)), func(ok bool) {
+			if !ok {
+				return
+			}
+			if err := cfg.History.Delete(ev.Key); err != nil {
+				ShowErrorNotification(cfg.MainWindow, "Delete Event", err.Error())
+				return
+			}
+			if cfg.refreshHistory != nil {
+				cfg.refreshHistory()
+			}
+		}, cfg.MainWindow)
+	})
+	deleteButton.Disable()
+
+	list.OnSelected = func(id widget.ListItemID) {
+		selected = id
+		openButton.Enable()
+		deleteButton.Enable()
+	}
+
+	top := container.NewVBox(
+		container.NewBorder(nil, nil, widget.NewLabel("Totals for"), nil, periodSelect),
+		totals,
+		widget.NewSeparator(),
+	)
+	return container.NewBorder(top, container.NewHBox(openButton, deleteButton), nil, nil, list)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/andrew-tawfik/outreach-routing/internal/app"
 	"github.com/andrew-tawfik/outreach-routing/internal/config"
 	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
+	"github.com/andrew-tawfik/outreach-routing/internal/palette"
 )
 
 const (
@@ -88,13 +89,13 @@ func (mv *MapView) getApiKey() error {
 
 	projectRoot, err := filepath.Abs(filepath.Join(".", ".."))
 	if err != nil {
-		return fmt.Errorf("failed to resolve project root:", err)
+		return fmt.Errorf("failed to resolve project root: %w", err)
 	}
 	credentialsPath := filepath.Join(projectRoot, "maps_config.json")
 
 	apiKeyFromFile, jsonErr := LoadMapsConfig(credentialsPath)
 	if jsonErr != nil {
-		return fmt.Errorf("failed to load api key:", err)
+		return fmt.Errorf("failed to load api key: %w", jsonErr)
 	}
 	mv.apiKey = apiKeyFromFile
 	return nil
@@ -165,7 +166,7 @@ func (mv *MapView) createLegend() *fyne.Container {
 	legendItems := container.NewVBox(legendTitle, widget.NewSeparator())
 
 	
-	depotRow := mv.createLegendRow("brown", "Depot", "555 Parkdale Ave", nil)
+	depotRow := mv.createLegendRow(palette.Depot, "Depot", "555 Parkdale Ave", nil)
 	legendItems.Add(depotRow)
 	legendItems.Add(widget.NewSeparator())
 
@@ -288,32 +289,11 @@ func NRGBAToHex(c color.Color) string {
 }
 
 func (mv *MapView) CreateColorMapping() {
-
-	
-	ColorMap := map[string]color.Color{
-		"brown":   color.NRGBA{139, 69, 19, 255},   
-		"red":     color.NRGBA{255, 0, 0, 255},     
-		"blue":    color.NRGBA{0, 0, 255, 255},     
-		"green":   color.NRGBA{0, 128, 0, 255},     
-		"orange":  color.NRGBA{255, 165, 0, 255},   
-		"purple":  color.NRGBA{128, 0, 128, 255},   
-		"cyan":    color.NRGBA{0, 255, 255, 255},   
-		"magenta": color.NRGBA{255, 0, 255, 255},   
-		"pink":    color.NRGBA{255, 192, 203, 255}, 
-		"teal":    color.NRGBA{0, 128, 128, 255},   
-		"indigo":  color.NRGBA{75, 0, 130, 255},    
-		"gold":    color.NRGBA{255, 215, 0, 255},   
-		"lime":    color.NRGBA{50, 205, 50, 255},   
-
+	mv.colorMap = make(map[string]color.Color, len(palette.Colors))
+	for name, c := range palette.Colors {
+		mv.colorMap[name] = c
 	}
-
-	
-	VehicleColors := []string{"red", "blue", "green", "orange", "purple", "cyan",
-		"magenta", "pink", "teal", "indigo", "gold", "lime"}
-
-	mv.colorMap = ColorMap
-	mv.colors = VehicleColors
-
+	mv.colors = palette.VehicleColors
 }
 
 
@@ -325,7 +305,7 @@ func (mv *MapView) buildMapURL() string {
 	params.Set("center", fmt.Sprintf("%f,%f", depotCoor.Lat, depotCoor.Long))
 
 	
-	depotColor := mv.colorMap["brown"]
+	depotColor := mv.colorMap[palette.Depot]
 	params.Add("markers", fmt.Sprintf("color:%s|label:M|%f,%f", NRGBAToHex(depotColor), depotCoor.Lat, depotCoor.Long))
 
 	
@@ -0,0 +1,218 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"github.com/andrew-tawfik/outreach-routing/internal/export"
+)
+
+// makeMainMenu builds the window menu. Exports use the routes as currently
+// shown, including manual edits.
+func (cfg *Config) makeMainMenu() *fyne.MainMenu {
+	return fyne.NewMainMenu(
+		fyne.NewMenu("File",
+			fyne.NewMenuItem("Open Session…", cfg.openSession),
+			fyne.NewMenuItem("Save Session…", cfg.saveSession),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem("Open Recorded Event…", cfg.openRecordedEvent),
+		),
+		fyne.NewMenu("Notify",
+			fyne.NewMenuItem("Send Driver Assignments…", cfg.showDriverNotifications),
+			fyne.NewMenuItem("Send Guest Arrival Texts…", cfg.showGuestNotifications),
+			fyne.NewMenuItem("Notification Settings…", cfg.showNotifySettings),
+		),
+		fyne.NewMenu("Export",
+			fyne.NewMenuItem("Save Itinerary Booklet (PDF)…", cfg.exportItineraryBooklet),
+			fyne.NewMenuItem("Save Driver Itineraries (PDF per Driver)…", cfg.exportItineraryPDFs),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem("Save Routes as GPX…", cfg.exportRouteFile(".gpx")),
+			fyne.NewMenuItem("Save Routes as KML…", cfg.exportRouteFile(".kml")),
+			fyne.NewMenuItem("Save Routes as GeoJSON…", cfg.exportRouteFile(".geojson")),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem("Write Drivers to Guest Sheet", cfg.exportAssignments),
+			fyne.NewMenuItem("Add Routes Tab to Sheet", cfg.exportRoutesSheet),
+		),
+	)
+}
+
+// currentRoutes returns the routing result to export, telling the
+// coordinator when there is none yet.
+func (cfg *Config) currentRoutes() (*RoutingProcess, bool) {
+	if cfg.Rp == nil {
+		ShowErrorNotification(cfg.MainWindow, "No Routes", "Run routing before exporting.")
+		return nil, false
+	}
+	return cfg.Rp, true
+}
+
+// exportRoutes returns the routes to export or send, refusing routes that
+// fail validation so a broken plan never reaches a driver.
+func (cfg *Config) exportRoutes() (*RoutingProcess, bool) {
+	rp, ok := cfg.currentRoutes()
+	if !ok || !cfg.checkRoutes("Export") {
+		return nil, false
+	}
+	return rp, true
+}
+
+// checkRoutes validates the current routes, logging and showing any
+// problem under title. It returns whether the routes are consistent.
+func (cfg *Config) checkRoutes(title string) bool {
+	rp := cfg.Rp
+	if rp == nil {
+		return true
+	}
+	if err := rp.rm.Validate(rp.lr, rp.ae); err != nil {
+		cfg.ErrorLog.Printf("%s: %v", title, err)
+		ShowErrorNotification(cfg.MainWindow, title, err.Error())
+		return false
+	}
+	return true
+}
+
+// runTask runs task off the UI thread behind the processing popup and shows
+// its result or error when done.
+func (cfg *Config) runTask(title string, task func() (string, error)) {
+	popup := ShowMessage(cfg.MainWindow)
+	popup.Show()
+
+	go func() {
+		message, err := task()
+
+		fyne.Do(func() {
+			popup.Hide()
+			if err != nil {
+				cfg.ErrorLog.Printf("%s: %v", title, err)
+				ShowErrorNotification(cfg.MainWindow, title, err.Error())
+				return
+			}
+			dialog.ShowInformation(title, message, cfg.MainWindow)
+		})
+	}()
+}
+
+func (cfg *Config) exportAssignments() {
+	rp, ok := cfg.exportRoutes()
+	if !ok {
+		return
+	}
+	cfg.runTask("Write Drivers to Sheet", func() (string, error) {
+		if err := rp.WriteAssignments(); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Driver and Stop # columns updated on %q.", rp.worksheet), nil
+	})
+}
+
+func (cfg *Config) exportRoutesSheet() {
+	rp, ok := cfg.exportRoutes()
+	if !ok {
+		return
+	}
+	cfg.runTask("Add Routes Tab", func() (string, error) {
+		title, err := rp.WriteRoutesSheet()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Routes written to the %q tab.", title), nil
+	})
+}
+
+// exportTitle names the event on printed itineraries and default file names.
+func (rp *RoutingProcess) exportTitle() string {
+	if title := strings.TrimSpace(rp.worksheet); title != "" {
+		return title
+	}
+	return fmt.Sprintf("%s %s", rp.ae.EventType, time.Now().Format("2006-01-02"))
+}
+
+// saveExport asks where to save a file of the given extension and writes
+// it in the background.
+func (cfg *Config) saveExport(title, fileName, ext string, write func(w io.Writer) error) {
+	save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			ShowErrorNotification(cfg.MainWindow, title, err.Error())
+			return
+		}
+		if writer == nil {
+			return
+		}
+
+		cfg.runTask(title, func() (string, error) {
+			defer writer.Close()
+			if err := write(writer); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Saved %s.", writer.URI().Name()), nil
+		})
+	}, cfg.MainWindow)
+	save.SetFileName(fileName + ext)
+	save.SetFilter(storage.NewExtensionFileFilter([]string{ext}))
+	save.Show()
+}
+
+func (cfg *Config) exportItineraryBooklet() {
+	rp, ok := cfg.exportRoutes()
+	if !ok {
+		return
+	}
+	itineraries := export.Itineraries(rp.rm, rp.lr)
+	opts := export.PDFOptions{Title: rp.exportTitle()}
+	cfg.saveExport("Save Itineraries", rp.exportTitle()+" itineraries", ".pdf", func(w io.Writer) error {
+		return export.WriteItineraryPDF(w, itineraries, opts)
+	})
+}
+
+// exportRouteFile saves the routes in one of the map formats, named by
+// extension: .gpx, .kml or .geojson.
+func (cfg *Config) exportRouteFile(ext string) func() {
+	writers := map[string]func(io.Writer, []export.Itinerary, string) error{
+		".gpx":     export.WriteGPX,
+		".kml":     export.WriteKML,
+		".geojson": export.WriteGeoJSON,
+	}
+	return func() {
+		rp, ok := cfg.exportRoutes()
+		if !ok {
+			return
+		}
+		itineraries := export.Itineraries(rp.rm, rp.lr)
+		title := rp.exportTitle()
+		cfg.saveExport("Save Routes", title+" routes", ext, func(w io.Writer) error {
+			return writers[ext](w, itineraries, title)
+		})
+	}
+}
+
+func (cfg *Config) exportItineraryPDFs() {
+	rp, ok := cfg.exportRoutes()
+	if !ok {
+		return
+	}
+
+	dialog.ShowFolderOpen(func(dir fyne.ListableURI, err error) {
+		if err != nil {
+			ShowErrorNotification(cfg.MainWindow, "Save Itineraries", err.Error())
+			return
+		}
+		if dir == nil {
+			return
+		}
+
+		itineraries := export.Itineraries(rp.rm, rp.lr)
+		opts := export.PDFOptions{Title: rp.exportTitle()}
+		cfg.runTask("Save Itineraries", func() (string, error) {
+			paths, err := export.WriteItineraryPDFs(dir.Path(), itineraries, opts)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Saved %d driver PDFs to %s.", len(paths), dir.Path()), nil
+		})
+	}, cfg.MainWindow)
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/andrew-tawfik/outreach-routing/internal/app"
+)
+
+// showNavigationLinks lists the Google Maps directions for a vehicle and a
+// Waze link per stop, each with a copy button, so the coordinator can send
+// them to the driver.
+func (cfg *Config) showNavigationLinks(index int, vehicle *app.Vehicle) {
+	if cfg.Rp == nil || len(vehicle.Guests) == 0 {
+		ShowErrorNotification(cfg.MainWindow, "Navigation Links", "This vehicle has no guests assigned.")
+		return
+	}
+
+	rows := container.NewVBox()
+
+	directions := vehicle.DirectionsURLs(cfg.Rp.lr.Depot)
+	for i, link := range directions {
+		label := "Google Maps directions"
+		if len(directions) > 1 {
+			label = fmt.Sprintf("Google Maps directions, part %d of %d", i+1, len(directions))
+		}
+		rows.Add(cfg.linkRow(label, link))
+	}
+
+	rows.Add(widget.NewSeparator())
+
+	stopNumbers := vehicle.StopNumbers()
+	last := 0
+	for i, g := range vehicle.Guests {
+		if stopNumbers[i] == last {
+			continue
+		}
+		last = stopNumbers[i]
+		rows.Add(cfg.linkRow(fmt.Sprintf("Stop %d: Waze to %s", last, g.FullAddress()), app.WazeURL(g.Coordinates)))
+	}
+
+	scroll := container.NewVScroll(rows)
+	scroll.SetMinSize(fyne.NewSize(560, 320))
+
+	d := dialog.NewCustom(fmt.Sprintf("%s Navigation", vehicle.DriverName(index)), "Close", scroll, cfg.MainWindow)
+	d.Show()
+}
+
+func (cfg *Config) linkRow(label, link string) fyne.CanvasObject {
+	copyButton := widget.NewButtonWithIcon("Copy", theme.ContentCopyIcon(), func() {
+		cfg.App.Clipboard().SetContent(link)
+		cfg.InfoLog.Printf("Copied %s", label)
+	})
+
+	var linkWidget fyne.CanvasObject = widget.NewLabel(label)
+	if u, err := url.Parse(link); err == nil {
+		linkWidget = widget.NewHyperlink(label, u)
+	}
+
+	return container.NewBorder(nil, nil, nil, copyButton, linkWidget)
+}
@@ -1,13 +1,16 @@
 package ui
 
 import (
+	"fmt"
 	"image/color"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
+	"github.com/andrew-tawfik/outreach-routing/internal/database"
 )
 
 
@@ -100,3 +103,78 @@ func ShowSuccess(window fyne.Window) {
 		})
 	}()
 }
+
+// ShowImportReport lists the rows that were skipped or flagged while reading
+// the sheet and lets the coordinator decide whether to route anyway.
+func ShowImportReport(window fyne.Window, report *database.ImportReport, onContinue func()) {
+	summary := widget.NewLabelWithStyle(report.GetSummary(), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+
+	details := widget.NewLabel(report.GetDetails())
+	details.Wrapping = fyne.TextWrapWord
+	scroll := container.NewVScroll(details)
+	scroll.SetMinSize(fyne.NewSize(520, 260))
+
+	hint := widget.NewLabel("Fix these rows in the sheet and run again, or continue routing without them.")
+	hint.Wrapping = fyne.TextWrapWord
+
+	content := container.NewBorder(summary, hint, nil, nil, scroll)
+
+	d := dialog.NewCustomConfirm("Import Report", "Continue Routing", "Cancel", content, func(ok bool) {
+		if ok {
+			onContinue()
+		}
+	}, window)
+	d.Resize(fyne.NewSize(600, 420))
+	d.Show()
+}
+
+// duplicateLine describes one row of a possible duplicate pair.
+func duplicateLine(g database.Guest) string {
+	line := fmt.Sprintf("Row %d: %s, %s", g.Row, g.Name, g.Address)
+	if g.Unit != "" {
+		line += " unit " + g.Unit
+	}
+	if g.PhoneNumber != "" {
+		line += ", " + g.PhoneNumber
+	}
+	return fmt.Sprintf("%s (%d)", line, g.GroupSize)
+}
+
+// ShowDuplicateMerge lists the rows that look like the same household and
+// merges the pairs the coordinator ticks into their first row before routing
+// continues. Nothing is ticked to start with.
+func ShowDuplicateMerge(window fyne.Window, event *database.Event, onContinue func()) {
+	checks := make([]*widget.Check, len(event.Duplicates))
+	rows := container.NewVBox()
+	for i, d := range event.Duplicates {
+		keep, drop := event.Guests[d.Keep], event.Guests[d.Drop]
+		checks[i] = widget.NewCheck(fmt.Sprintf("Merge row %d into row %d (%s)", drop.Row, keep.Row, d.Reason), nil)
+
+		details := widget.NewLabel(duplicateLine(keep) + "\n" + duplicateLine(drop))
+		details.Wrapping = fyne.TextWrapWord
+		rows.Add(checks[i])
+		rows.Add(details)
+		rows.Add(widget.NewSeparator())
+	}
+
+	scroll := container.NewVScroll(rows)
+	scroll.SetMinSize(fyne.NewSize(560, 300))
+	hint := widget.NewLabel("Tick the rows that are the same guest. Merged rows are routed once, with the larger group size and any details missing from the first row.")
+	hint.Wrapping = fyne.TextWrapWord
+
+	d := dialog.NewCustomConfirm("Possible Duplicates", "Continue Routing", "Cancel", container.NewBorder(nil, hint, nil, nil, scroll), func(ok bool) {
+		if !ok {
+			return
+		}
+		var merge []database.Duplicate
+		for i, check := range checks {
+			if check.Checked {
+				merge = append(merge, event.Duplicates[i])
+			}
+		}
+		event.MergeDuplicates(merge)
+		onContinue()
+	}, window)
+	d.Resize(fyne.NewSize(640, 460))
+	d.Show()
+}
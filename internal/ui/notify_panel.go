@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/andrew-tawfik/outreach-routing/internal/notify"
+)
+
+const noContact = "(not assigned)"
+
+// driverMessageRow is one vehicle in the send dialog: who drives it, the
+// editable message and how delivery went.
+type driverMessageRow struct {
+	index   int
+	contact *widget.Select
+	preview *widget.Entry
+	status  *widget.Label
+}
+
+// driverMessage builds the text sent to the driver of the vehicle at index.
+func (rp *RoutingProcess) driverMessage(index int) string {
+	v := &rp.rm.Vehicles[index]
+	greeting := "Hi"
+	if v.Driver != "" {
+		greeting = "Hi " + v.Driver
+	}
+	return fmt.Sprintf("%s, here is your route for %s.\n\n%s",
+		greeting, rp.exportTitle(), v.GetVehicleRouteInfo(index, rp.ae, rp.lr))
+}
+
+// showDriverNotifications previews each driver's message and sends them all
+// through the configured notifier, showing the delivery status per message.
+func (cfg *Config) showDriverNotifications() {
+	rp, ok := cfg.exportRoutes()
+	if !ok {
+		return
+	}
+
+	contacts := cfg.loadContacts()
+	byName := make(map[string]Contact, len(contacts))
+	names := []string{noContact}
+	for _, c := range contacts {
+		byName[c.Name] = c
+		names = append(names, c.Name)
+	}
+
+	channel := cfg.channel()
+	rows := make([]*driverMessageRow, 0, len(rp.rm.Vehicles))
+	list := container.NewVBox()
+
+	for i := range rp.rm.Vehicles {
+		v := &rp.rm.Vehicles[i]
+		if len(v.Guests) == 0 {
+			continue
+		}
+
+		row := &driverMessageRow{
+			index:   i,
+			preview: widget.NewMultiLineEntry(),
+			status:  widget.NewLabel(""),
+		}
+		row.preview.Wrapping = fyne.TextWrapWord
+		row.preview.SetMinRowsVisible(5)
+
+		row.contact = widget.NewSelect(names, func(name string) {
+			if name == noContact {
+				name = ""
+			}
+			v.Driver = name
+			row.preview.SetText(rp.driverMessage(row.index))
+			row.status.SetText(recipientStatus(byName[name], channel))
+		})
+		if _, known := byName[v.Driver]; known {
+			row.contact.SetSelected(v.Driver)
+		} else {
+			row.contact.SetSelected(noContact)
+		}
+
+		title := widget.NewLabelWithStyle(fmt.Sprintf("Vehicle %d", i+1), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+		list.Add(container.NewBorder(nil, nil, title, row.status, row.contact))
+		list.Add(row.preview)
+		list.Add(widget.NewSeparator())
+		rows = append(rows, row)
+	}
+
+	var sendButton *widget.Button
+	sendButton = widget.NewButton("Send All", func() {
+		sendButton.Disable()
+		cfg.withCredentials(cfg.channel(), func() {
+			cfg.sendDriverMessages(rp, cfg.notifier(), cfg.channel(), byName, rows, func() {
+				sendButton.Enable()
+			})
+		}, sendButton.Enable)
+	})
+	sendButton.Importance = widget.HighImportance
+
+	settingsButton := widget.NewButton("Settings…", cfg.showNotifySettings)
+
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(700, 480))
+
+	content := container.NewBorder(nil, container.NewHBox(sendButton, settingsButton), nil, nil, scroll)
+
+	d := dialog.NewCustom("Send Driver Assignments", "Close", content, cfg.MainWindow)
+	d.SetOnClosed(cfg.refreshOutput)
+	d.Show()
+}
+
+func recipientStatus(c Contact, channel string) string {
+	if c.Name == "" {
+		return "No driver"
+	}
+	if c.recipient(channel) == "" {
+		if channel == channelSMS {
+			return fmt.Sprintf("No phone for %s", c.Name)
+		}
+		return fmt.Sprintf("No email for %s", c.Name)
+	}
+	return "Ready"
+}
+
+// sendDriverMessages sends the previewed messages to each row's driver.
+func (cfg *Config) sendDriverMessages(rp *RoutingProcess, notifier notify.Notifier, channel string,
+	contacts map[string]Contact, rows []*driverMessageRow, done func()) {
+
+	messages := make([]notify.Message, len(rows))
+	statuses := make([]*widget.Label, len(rows))
+	for i, row := range rows {
+		c := contacts[rp.rm.Vehicles[row.index].Driver]
+		messages[i] = notify.Message{
+			To:      c.recipient(channel),
+			Subject: fmt.Sprintf("Your route for %s", rp.exportTitle()),
+			Body:    row.preview.Text,
+		}
+		statuses[i] = row.status
+	}
+	cfg.sendMessages(notifier, "driver", messages, statuses, done)
+}
+
+// sendMessages sends messages one at a time off the UI thread, updating the
+// matching status label as it goes. Messages without a recipient are
+// skipped.
+func (cfg *Config) sendMessages(notifier notify.Notifier, kind string, messages []notify.Message,
+	statuses []*widget.Label, done func()) {
+
+	for _, status := range statuses {
+		status.SetText("Waiting…")
+	}
+
+	go func() {
+		sent := 0
+		for i, msg := range messages {
+			status := statuses[i]
+			if msg.To == "" {
+				fyne.Do(func() { status.SetText("Skipped: no recipient") })
+				continue
+			}
+
+			fyne.Do(func() { status.SetText("Sending…") })
+			err := notifier.Send(msg)
+			fyne.Do(func() {
+				if err != nil {
+					cfg.ErrorLog.Printf("notify %s %s: %v", kind, msg.To, err)
+					status.SetText("Failed: " + err.Error())
+					return
+				}
+				status.SetText("Sent ✓")
+			})
+			if err == nil {
+				sent++
+			}
+		}
+
+		fyne.Do(func() {
+			cfg.InfoLog.Printf("Sent %d of %d %s messages", sent, len(messages), kind)
+			done()
+		})
+	}()
+}
@@ -0,0 +1,304 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/andrew-tawfik/outreach-routing/internal/notify"
+)
+
+// Contact is a driver who can be sent their route.
+type Contact struct {
+	Name  string
+	Phone string
+	Email string
+}
+
+const (
+	channelEmail = "Email"
+	channelSMS   = "SMS"
+	channelLog   = "Log only"
+
+	prefContacts     = "notify.contacts"
+	prefChannel      = "notify.channel"
+	prefSMTPHost     = "notify.smtp.host"
+	prefSMTPPort     = "notify.smtp.port"
+	prefSMTPUser     = "notify.smtp.username"
+	prefSMTPPassword = "notify.smtp.password"
+	prefSMTPFrom     = "notify.smtp.from"
+	prefSMSURL       = "notify.sms.url"
+	prefSMSAccount   = "notify.sms.account"
+	prefSMSToken     = "notify.sms.token"
+	prefSMSFrom      = "notify.sms.from"
+	prefETADeparture = "notify.eta.departure"
+	prefETASpeed     = "notify.eta.speed"
+	prefETADwell     = "notify.eta.dwell"
+)
+
+// secret returns a credential entered this session. Credentials are kept in
+// memory only and asked for again after a restart.
+func (cfg *Config) secret(key string) string {
+	return cfg.secrets[key]
+}
+
+func (cfg *Config) setSecret(key, value string) {
+	if cfg.secrets == nil {
+		cfg.secrets = make(map[string]string)
+	}
+	cfg.secrets[key] = value
+}
+
+// forgetSavedSecrets removes the credentials earlier versions stored in
+// plain text with the preferences.
+func (cfg *Config) forgetSavedSecrets() {
+	prefs := cfg.App.Preferences()
+	prefs.RemoveValue(prefSMTPPassword)
+	prefs.RemoveValue(prefSMSToken)
+}
+
+// missingSecret returns the key and label of the credential channel needs
+// but has not been given this session, or "" when nothing is missing.
+func (cfg *Config) missingSecret(channel string) (string, string) {
+	prefs := cfg.App.Preferences()
+	switch {
+	case channel == channelEmail && prefs.String(prefSMTPUser) != "" && cfg.secret(prefSMTPPassword) == "":
+		return prefSMTPPassword, "SMTP password"
+	case channel == channelSMS && prefs.String(prefSMSAccount) != "" && cfg.secret(prefSMSToken) == "":
+		return prefSMSToken, "SMS auth token"
+	}
+	return "", ""
+}
+
+// withCredentials asks for the credential channel is missing, if any, and
+// then calls send. cancel is called instead when the prompt is dismissed.
+func (cfg *Config) withCredentials(channel string, send, cancel func()) {
+	key, label := cfg.missingSecret(channel)
+	if key == "" {
+		send()
+		return
+	}
+
+	entry := widget.NewPasswordEntry()
+	item := widget.NewFormItem(label, entry)
+	item.HintText = "Kept until the app is closed, never saved"
+	dialog.ShowForm("Sign In to Send", "Send", "Cancel", []*widget.FormItem{item}, func(ok bool) {
+		if !ok {
+			cancel()
+			return
+		}
+		cfg.setSecret(key, entry.Text)
+		send()
+	}, cfg.MainWindow)
+}
+
+func (cfg *Config) loadContacts() []Contact {
+	var contacts []Contact
+	raw := cfg.App.Preferences().String(prefContacts)
+	if raw == "" {
+		return contacts
+	}
+	if err := json.Unmarshal([]byte(raw), &contacts); err != nil {
+		cfg.ErrorLog.Printf("could not read saved contacts: %v", err)
+	}
+	return contacts
+}
+
+func (cfg *Config) saveContacts(contacts []Contact) {
+	data, err := json.Marshal(contacts)
+	if err != nil {
+		cfg.ErrorLog.Printf("could not save contacts: %v", err)
+		return
+	}
+	cfg.App.Preferences().SetString(prefContacts, string(data))
+}
+
+// formatContacts and parseContacts convert the contact list to and from one
+// "name, phone, email" line per driver, as edited in the settings dialog.
+func formatContacts(contacts []Contact) string {
+	lines := make([]string, len(contacts))
+	for i, c := range contacts {
+		lines[i] = strings.Join([]string{c.Name, c.Phone, c.Email}, ", ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func parseContacts(text string) ([]Contact, error) {
+	contacts := make([]Contact, 0)
+	for n, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, ",")
+		for len(fields) < 3 {
+			fields = append(fields, "")
+		}
+		c := Contact{
+			Name:  strings.TrimSpace(fields[0]),
+			Phone: strings.TrimSpace(fields[1]),
+			Email: strings.TrimSpace(strings.Join(fields[2:], ",")),
+		}
+		if c.Name == "" {
+			return nil, fmt.Errorf("line %d: a name is required", n+1)
+		}
+		contacts = append(contacts, c)
+	}
+	return contacts, nil
+}
+
+// recipient returns the address of c for the given channel.
+func (c Contact) recipient(channel string) string {
+	switch channel {
+	case channelEmail:
+		return c.Email
+	case channelSMS:
+		return c.Phone
+	default:
+		if c.Email != "" {
+			return c.Email
+		}
+		return c.Phone
+	}
+}
+
+// notifier builds the notifier for the channel chosen in the settings.
+func (cfg *Config) notifier() notify.Notifier {
+	prefs := cfg.App.Preferences()
+	switch prefs.StringWithFallback(prefChannel, channelLog) {
+	case channelEmail:
+		return &notify.SMTPNotifier{
+			Host:     prefs.String(prefSMTPHost),
+			Port:     prefs.IntWithFallback(prefSMTPPort, 587),
+			Username: prefs.String(prefSMTPUser),
+			Password: cfg.secret(prefSMTPPassword),
+			From:     prefs.String(prefSMTPFrom),
+		}
+	case channelSMS:
+		return cfg.smsNotifier()
+	default:
+		return &notify.LogNotifier{Logger: cfg.InfoLog}
+	}
+}
+
+// guestNotifier builds the notifier for guest texts, which always go by SMS
+// unless the channel is set to log only.
+func (cfg *Config) guestNotifier() notify.Notifier {
+	if cfg.guestChannel() == channelLog {
+		return &notify.LogNotifier{Logger: cfg.InfoLog}
+	}
+	return cfg.smsNotifier()
+}
+
+func (cfg *Config) guestChannel() string {
+	if cfg.channel() == channelLog {
+		return channelLog
+	}
+	return channelSMS
+}
+
+func (cfg *Config) smsNotifier() *notify.HTTPSMSNotifier {
+	prefs := cfg.App.Preferences()
+	return &notify.HTTPSMSNotifier{
+		URL:       prefs.String(prefSMSURL),
+		AccountID: prefs.String(prefSMSAccount),
+		AuthToken: cfg.secret(prefSMSToken),
+		From:      prefs.String(prefSMSFrom),
+	}
+}
+
+func (cfg *Config) channel() string {
+	return cfg.App.Preferences().StringWithFallback(prefChannel, channelLog)
+}
+
+// showNotifySettings edits the delivery channel, its account settings and
+// the driver contact list.
+func (cfg *Config) showNotifySettings() {
+	prefs := cfg.App.Preferences()
+
+	entry := func(key string) *widget.Entry {
+		e := widget.NewEntry()
+		e.SetText(prefs.String(key))
+		return e
+	}
+	password := func(key string) *widget.Entry {
+		e := widget.NewPasswordEntry()
+		e.SetText(cfg.secret(key))
+		return e
+	}
+
+	channelSelect := widget.NewSelect([]string{channelLog, channelEmail, channelSMS}, nil)
+	channelSelect.SetSelected(cfg.channel())
+
+	smtpHost := entry(prefSMTPHost)
+	smtpPort := widget.NewEntry()
+	smtpPort.SetText(strconv.Itoa(prefs.IntWithFallback(prefSMTPPort, 587)))
+	smtpUser := entry(prefSMTPUser)
+	smtpPassword := password(prefSMTPPassword)
+	smtpFrom := entry(prefSMTPFrom)
+
+	smsURL := entry(prefSMSURL)
+	smsURL.SetPlaceHolder("https://api.twilio.com/2010-04-01/Accounts/<id>/Messages.json")
+	smsAccount := entry(prefSMSAccount)
+	smsToken := password(prefSMSToken)
+	smsFrom := entry(prefSMSFrom)
+
+	contacts := widget.NewMultiLineEntry()
+	contacts.SetPlaceHolder("Mina, 613-555-0101, mina@example.com")
+	contacts.SetText(formatContacts(cfg.loadContacts()))
+	contacts.SetMinRowsVisible(6)
+
+	form := widget.NewForm(
+		widget.NewFormItem("Send by", channelSelect),
+		widget.NewFormItem("SMTP server", smtpHost),
+		widget.NewFormItem("SMTP port", smtpPort),
+		widget.NewFormItem("SMTP username", smtpUser),
+		widget.NewFormItem("SMTP password", smtpPassword),
+		widget.NewFormItem("Email from", smtpFrom),
+		widget.NewFormItem("SMS gateway URL", smsURL),
+		widget.NewFormItem("SMS account ID", smsAccount),
+		widget.NewFormItem("SMS auth token", smsToken),
+		widget.NewFormItem("SMS from number", smsFrom),
+		widget.NewFormItem("Drivers", contacts),
+	)
+	hint := widget.NewLabel("One driver per line: name, phone, email. Leave the SMTP username empty for a local test server. " +
+		"The SMTP password and SMS token are kept until the app is closed and are never saved.")
+	hint.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVScroll(container.NewVBox(form, hint))
+	content.SetMinSize(fyne.NewSize(620, 480))
+
+	d := dialog.NewCustomConfirm("Notification Settings", "Save", "Cancel", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		parsed, err := parseContacts(contacts.Text)
+		if err != nil {
+			ShowErrorNotification(cfg.MainWindow, "Notification Settings", err.Error())
+			return
+		}
+		port, err := strconv.Atoi(strings.TrimSpace(smtpPort.Text))
+		if err != nil {
+			ShowErrorNotification(cfg.MainWindow, "Notification Settings", "SMTP port must be a number")
+			return
+		}
+
+		prefs.SetString(prefChannel, channelSelect.Selected)
+		prefs.SetString(prefSMTPHost, strings.TrimSpace(smtpHost.Text))
+		prefs.SetInt(prefSMTPPort, port)
+		prefs.SetString(prefSMTPUser, strings.TrimSpace(smtpUser.Text))
+		cfg.setSecret(prefSMTPPassword, smtpPassword.Text)
+		prefs.SetString(prefSMTPFrom, strings.TrimSpace(smtpFrom.Text))
+		prefs.SetString(prefSMSURL, strings.TrimSpace(smsURL.Text))
+		prefs.SetString(prefSMSAccount, strings.TrimSpace(smsAccount.Text))
+		cfg.setSecret(prefSMSToken, smsToken.Text)
+		prefs.SetString(prefSMSFrom, strings.TrimSpace(smsFrom.Text))
+		cfg.saveContacts(parsed)
+		cfg.InfoLog.Println("Notification settings saved")
+	}, cfg.MainWindow)
+	d.Show()
+}
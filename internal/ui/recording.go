@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"github.com/andrew-tawfik/outreach-routing/internal/app"
+	"github.com/andrew-tawfik/outreach-routing/internal/database"
+	"github.com/andrew-tawfik/outreach-routing/internal/geoapi"
+)
+
+// recordedEvent is everything a run read from outside the app: the worksheet
+// rows, the duplicate rows merged away, the geocoding and distance matrix
+// responses and any earlier assignments the routes started from, along with
+// the seed of grocery routes. Replaying it routes the same event offline,
+// without credentials or API keys.
+type recordedEvent struct {
+	Recorded  time.Time
+	Worksheet string
+	EventType string
+	Rows      [][]string
+	Merged    []database.Duplicate `json:",omitempty"`
+	Previous  app.Baseline         `json:",omitempty"`
+	Seed      int64                `json:",omitempty"`
+	HTTP      geoapi.Recording
+}
+
+// RecordRouteEvent routes event like RouteEvent while recording the service
+// responses. The recording is returned even when routing fails, so the
+// failure can be reproduced.
+func RecordRouteEvent(event *database.Event, prev app.Baseline) (*RoutingProcess, *recordedEvent, error) {
+	rec := geoapi.StartRecording()
+	defer geoapi.StopRecording()
+
+	rp, err := RouteEvent(event, prev, 0)
+	recorded := &recordedEvent{
+		Recorded:  time.Now(),
+		Worksheet: event.Worksheet,
+		EventType: event.EventType,
+		Rows:      event.Rows,
+		Merged:    event.Merged,
+		Previous:  prev,
+		HTTP:      *rec,
+	}
+	if rp != nil {
+		recorded.Seed = rp.rm.Seed
+	}
+	return rp, recorded, err
+}
+
+// OpenRecordedEvent routes a recorded run offline. Event files saved by
+// earlier versions, which hold geocoded guests and the distance matrix
+// instead of a recording, are routed as well.
+func OpenRecordedEvent(r io.Reader) (*RoutingProcess, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var recorded recordedEvent
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		return nil, fmt.Errorf("not a recorded event: %w", err)
+	}
+	if recorded.Rows == nil {
+		s, err := app.LoadSession(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return sessionProcess(s), nil
+	}
+
+	source := database.RecordedWorksheet(recorded.Worksheet, recorded.Rows)
+	event, err := source.ProcessWorksheet(recorded.Worksheet, recorded.EventType)
+	if err != nil {
+		return nil, fmt.Errorf("could not process recorded worksheet: %w", err)
+	}
+	event.MergeDuplicates(recorded.Merged)
+
+	geoapi.StartReplay(&recorded.HTTP)
+	defer geoapi.StopRecording()
+	return RouteEvent(event, recorded.Previous, recorded.Seed)
+}
+
+func (cfg *Config) saveRecording(recorded *recordedEvent) {
+	name := fmt.Sprintf("recording %s", recorded.Worksheet)
+	cfg.saveExport("Save Recording", name, ".json", func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(recorded)
+	})
+}
+
+func (cfg *Config) openRecordedEvent() {
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			ShowErrorNotification(cfg.MainWindow, "Open Recorded Event", err.Error())
+			return
+		}
+		if reader == nil {
+			return
+		}
+
+		popup := ShowMessage(cfg.MainWindow)
+		popup.Show()
+
+		go func() {
+			defer reader.Close()
+			result, err := OpenRecordedEvent(reader)
+
+			fyne.Do(func() {
+				popup.Hide()
+				if err != nil {
+					cfg.ErrorLog.Printf("open recorded event %s: %v", reader.URI().Name(), err)
+					ShowErrorNotification(cfg.MainWindow, "Open Recorded Event", err.Error())
+					return
+				}
+				cfg.InfoLog.Printf("Replayed %s", reader.URI().Name())
+				cfg.showResult(result)
+				cfg.reportDispatch(result)
+			})
+		}()
+	}, cfg.MainWindow)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	open.Show()
+}
@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/andrew-tawfik/outreach-routing/internal/database"
+)
+
+// fakeServices answers the geocoding and routing services the way the real
+// ones would, placing every address at a fixed spot near the depot.
+type fakeServices struct{}
+
+func (fakeServices) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body any
+	switch req.URL.Host {
+	case "nominatim.openstreetmap.org":
+		body = map[string]any{"features": []any{map[string]any{
+			"properties": map[string]any{"display_name": "555, Parkdale Avenue, Ottawa", "addresstype": "house"},
+			"geometry":   map[string]any{"coordinates": []float64{-75.726, 45.397}},
+		}}}
+	case "maps.googleapis.com":
+		address := req.URL.Query().Get("address")
+		h := fnv.New32a()
+		h.Write([]byte(address))
+		angle := float64(h.Sum32()%360) * math.Pi / 180
+		body = map[string]any{"status": "OK", "results": []any{map[string]any{
+			"formatted_address": strings.TrimSuffix(address, " Ottawa, ON, Canada") + ", Ottawa",
+			"types":             []string{"street_address"},
+			"geometry": map[string]any{
+				"location_type": "ROOFTOP",
+				"location":      map[string]float64{"lat": 45.397 + 0.04*math.Sin(angle), "lng": -75.726 + 0.04*math.Cos(angle)},
+			},
+		}}}
+	case "router.project-osrm.org":
+		_, list, _ := strings.Cut(req.URL.Path, "/driving/")
+		var points [][2]float64
+		for _, p := range strings.Split(list, ";") {
+			long, lat, _ := strings.Cut(p, ",")
+			x, _ := strconv.ParseFloat(long, 64)
+			y, _ := strconv.ParseFloat(lat, 64)
+			points = append(points, [2]float64{x, y})
+		}
+		distances := make([][]float64, len(points))
+		for i := range points {
+			for j := range points {
+				distances[i] = append(distances[i], 100000*math.Hypot(points[i][0]-points[j][0], points[i][1]-points[j][1]))
+			}
+		}
+		body = map[string]any{"code": "Ok", "distances": distances}
+	default:
+		return nil, fmt.Errorf("unexpected request to %s", req.URL)
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(data)),
+		Request:    req,
+	}, nil
+}
+
+// useFakeServices sends requests to fakeServices and gives the geocoder a
+// key, which it reads from maps_config.json beside the working directory.
+func useFakeServices(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "maps_config.json"), []byte(`{"maps_api_key": "test"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	work := filepath.Join(dir, "work")
+	if err := os.Mkdir(work, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(work)
+
+	saved := http.DefaultTransport
+	http.DefaultTransport = fakeServices{}
+	t.Cleanup(func() { http.DefaultTransport = saved })
+}
+
+func groceryEvent(t *testing.T) *database.Event {
+	t.Helper()
+	title := "Grocery June 14"
+	rows := [][]string{{"Status", "Name", "Group Size", "Number", "Address"}}
+	for i := 1; i <= 10; i++ {
+		rows = append(rows, []string{"Grocery Only", fmt.Sprintf("Guest %d", i), "1", "613-555-0100", fmt.Sprintf("%d Bank St", 100*i)})
+	}
+	event, err := database.RecordedWorksheet(title, rows).ProcessWorksheet(title, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return event
+}
+
+// vehicleGuests lists the guests of each vehicle by name.
+func vehicleGuests(rp *RoutingProcess) [][]string {
+	var vehicles [][]string
+	for _, v := range rp.rm.Vehicles {
+		var names []string
+		for _, g := range v.Guests {
+			names = append(names, g.Name)
+		}
+		vehicles = append(vehicles, names)
+	}
+	return vehicles
+}
+
+func TestReplayedGroceryRecordingKeepsRoutes(t *testing.T) {
+	useFakeServices(t)
+
+	rp, recorded, err := RecordRouteEvent(groceryEvent(t), nil)
+	if err != nil {
+		t.Fatalf("RecordRouteEvent: %v", err)
+	}
+	if len(rp.ae.ApiErrors.FailedGuests) > 0 {
+		t.Fatalf("geocoding failed: %v", rp.ae.ApiErrors.FailedGuests)
+	}
+	if recorded.Seed == 0 || recorded.Seed != rp.rm.Seed {
+		t.Fatalf("recorded seed %d, routes seeded with %d", recorded.Seed, rp.rm.Seed)
+	}
+	want := fmt.Sprint(vehicleGuests(rp))
+
+	data, err := json.Marshal(recorded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for run := 1; run <= 2; run++ {
+		replayed, err := OpenRecordedEvent(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("replay %d: %v", run, err)
+		}
+		if got := fmt.Sprint(vehicleGuests(replayed)); got != want {
+			t.Errorf("replay %d routes %s, recorded run routed %s", run, got, want)
+		}
+		if replayed.rm.Seed != recorded.Seed {
+			t.Errorf("replay %d seeded with %d, want %d", run, replayed.rm.Seed, recorded.Seed)
+		}
+	}
+}
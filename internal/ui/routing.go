@@ -1,20 +1,30 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/andrew-tawfik/outreach-routing/internal/app"
 	"github.com/andrew-tawfik/outreach-routing/internal/converter"
+	"github.com/andrew-tawfik/outreach-routing/internal/coordinates"
 	"github.com/andrew-tawfik/outreach-routing/internal/database"
+	"github.com/andrew-tawfik/outreach-routing/internal/geoapi"
 )
 
 type RoutingProcess struct {
 	rm *app.RouteManager
 	ae *app.Event
 	lr *app.LocationRegistry
+
+	source    string
+	worksheet string
+
+	dispatchErr error
 }
 
-func ProcessEvent(googleSheetURL string) (*RoutingProcess, error) {
+// OpenSpreadsheet fetches the spreadsheet behind a Google Sheets URL.
+func OpenSpreadsheet(googleSheetURL string) (*database.Database, error) {
 
 	spreadsheetID, err := database.ExtractIDFromURL(googleSheetURL)
 
@@ -26,15 +36,63 @@ func ProcessEvent(googleSheetURL string) (*RoutingProcess, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not initialize sheet client: %v", err)
 	}
+	return db, nil
+}
+
+// OpenGuestSource opens a guest list from either a Google Sheets URL or the
+// path of an exported CSV or Excel file.
+func OpenGuestSource(location string) (database.GuestSource, error) {
+	if database.IsGuestFile(location) {
+		return database.OpenFile(location)
+	}
+	return OpenSpreadsheet(location)
+}
+
+// sourceKey identifies the guest list a location refers to, or returns ""
+// when the location is not yet a usable URL or file path.
+func sourceKey(location string) string {
+	if database.IsGuestFile(location) {
+		return location
+	}
+	id, err := database.ExtractIDFromURL(location)
+	if err != nil {
+		return ""
+	}
+	return id
+}
+
+// LoadEvent reads the guest list from one worksheet without routing it, so
+// the import report can be reviewed first. An empty worksheet means the most
+// recent one and an empty eventType is inferred from the worksheet title.
+func LoadEvent(location, worksheet, eventType string) (*database.Event, error) {
 
-	event, err := db.ProcessEvent()
+	source, err := OpenGuestSource(location)
+	if err != nil {
+		return nil, err
+	}
+
+	if worksheet == "" {
+		worksheet = source.LatestWorksheet()
+	}
+
+	event, err := source.ProcessWorksheet(worksheet, eventType)
 	if err != nil {
 		return nil, fmt.Errorf("could not process event: %v", err)
 	}
+	event.Source = location
+	return event, nil
+}
+
+// RouteEvent geocodes and routes an event. When prev holds an earlier
+// event's assignments, returning guests keep their drivers. Grocery routes
+// are seeded with seed, or a random seed when it is zero. Guests the
+// routing could not place do not fail the run; they are reported by
+// reportDispatch and shown in the Unassigned column.
+func RouteEvent(event *database.Event, prev app.Baseline, seed int64) (*RoutingProcess, error) {
 
 	geoEvent := converter.MapDatabaseEventToHttp(event)
 
-	err = geoEvent.RequestGuestCoordiantes()
+	err := geoEvent.RequestGuestCoordiantes()
 	if err != nil {
 		return nil, fmt.Errorf("could not geocode addresses: %w", err)
 	}
@@ -46,12 +104,21 @@ func ProcessEvent(googleSheetURL string) (*RoutingProcess, error) {
 
 	appEvent, lr := converter.MapDatabaseGeoEventToApp(geoEvent)
 
-	RouteManager := app.OrchestateDispatch(lr, appEvent)
+	var RouteManager *app.RouteManager
+	var dispatchErr error
+	if prev != nil {
+		RouteManager, dispatchErr = app.WarmStartDispatch(lr, appEvent, prev)
+	} else {
+		RouteManager, dispatchErr = app.OrchestateDispatch(lr, appEvent, seed)
+	}
 
 	return &RoutingProcess{
-		rm: RouteManager,
-		ae: appEvent,
-		lr: lr,
+		rm:          RouteManager,
+		ae:          appEvent,
+		lr:          lr,
+		source:      event.Source,
+		worksheet:   event.Worksheet,
+		dispatchErr: dispatchErr,
 	}, nil
 }
 
@@ -59,31 +126,152 @@ func (rp *RoutingProcess) String() string {
 	return rp.rm.Display(rp.ae, rp.lr)
 }
 
-func ProcessJsonEvent(eventType int) (*RoutingProcess, error) {
+// GuestResolution is the coordinator's fix for one guest that failed to
+// geocode. When Coordinates is nil the Address is geocoded again.
+type GuestResolution struct {
+	Index       int
+	Address     string
+	Coordinates *coordinates.GuestCoordinates
+}
+
+// ResolveGuests geocodes the corrected guests and inserts them into the
+// existing routes. Guests that still fail stay in the event's ApiErrors.
+func (rp *RoutingProcess) ResolveGuests(resolutions []GuestResolution) error {
+	failed := rp.ae.ApiErrors.FailedGuests
+	resolved := make(map[int]bool)
+	remaining := make([]geoapi.FailedGuest, 0)
+	guests := make([]app.Guest, 0, len(resolutions))
 
-	var appEvent app.Event
-	var lr app.LocationRegistry
-	var err error
-	if eventType == 0 {
-		appEvent, lr, err = app.LoadAppDataFromFile("data_dinner.json")
-		if err != nil {
-			return nil, fmt.Errorf("could not load json event information. %w", err)
+	for _, res := range resolutions {
+		if res.Index < 0 || res.Index >= len(failed) {
+			continue
 		}
-	} else {
-		appEvent, lr, err = app.LoadAppDataFromFile("data_grocery.json")
+		geoGuest := failed[res.Index].Guest
+		geoGuest.Address = res.Address
+		resolved[res.Index] = true
+
+		if res.Coordinates != nil {
+			geoGuest.Coordinates = *res.Coordinates
+		} else {
+			coor, formatted, err := geoapi.GeocodeAddress(res.Address)
+			if err != nil {
+				remaining = append(remaining, geoapi.NewFailedGuest(geoGuest, err))
+				continue
+			}
+			geoGuest.Coordinates = coor
+			geoGuest.Address = formatted
+		}
+		guests = append(guests, converter.MapGeoGuestToApp(geoGuest))
+	}
+
+	if err := rp.registerLocations(guests); err != nil {
+		return fmt.Errorf("could not retreive distance matrix: %w", err)
+	}
+
+	for _, g := range guests {
+		rp.rm.InsertGuest(g, rp.ae, rp.lr)
+	}
+
+	for i, fg := range failed {
+		if !resolved[i] {
+			remaining = append(remaining, fg)
+		}
+	}
+	rp.ae.ApiErrors.FailedGuests = remaining
+	return nil
+}
+
+// registerLocations adds the guests' destinations to the location registry,
+// fetching a new distance matrix first when any destination is new so that
+// a failed request leaves the registry untouched. A guest whose address is
+// already registered is moved to the coordinates it is registered at.
+func (rp *RoutingProcess) registerLocations(guests []app.Guest) error {
+	coords := rp.lr.Coordinates()
+	known := make(map[coordinates.GuestCoordinates]bool)
+	for _, c := range coords[1:] {
+		known[c] = true
+	}
+
+	seenAddress := make(map[string]bool)
+	for addr := range rp.lr.CoordianteMap.CoordinateToAddress {
+		seenAddress[addr] = true
+	}
+
+	added := false
+	for _, g := range guests {
+		if seenAddress[g.Address] || known[g.Coordinates] {
+			continue
+		}
+		seenAddress[g.Address] = true
+		known[g.Coordinates] = true
+		coords = append(coords, g.Coordinates)
+		added = true
+	}
+
+	var matrix [][]float64
+	if added {
+		m, err := geoapi.FetchDistanceMatrix(coords)
 		if err != nil {
-			return nil, fmt.Errorf("could not load json event information. %w", err)
+			return err
 		}
+		matrix = m
+	}
+
+	for i := range guests {
+		_, coord, _ := rp.lr.AddLocation(guests[i].Address, guests[i].Coordinates, guests[i].GroupSize)
+		guests[i].Coordinates = coord
 	}
+	if matrix != nil {
+		rp.lr.DistanceMatrix = matrix
+	}
+	return nil
+}
 
-	RouteManager := app.OrchestateDispatch(&lr, &appEvent)
+// openSourceSpreadsheet fetches a fresh copy of the Google Sheet this run was
+// imported from, so rows edited since the import are taken into account.
+func (rp *RoutingProcess) openSourceSpreadsheet() (*database.Database, error) {
+	if rp.source == "" || database.IsGuestFile(rp.source) {
+		return nil, fmt.Errorf("routes can only be written back to a Google Sheet")
+	}
+	return OpenSpreadsheet(rp.source)
+}
 
-	RouteManager.Display(&appEvent, &lr)
+// WriteAssignments fills the Driver and Stop # columns of the guest sheet.
+func (rp *RoutingProcess) WriteAssignments() error {
+	db, err := rp.openSourceSpreadsheet()
+	if err != nil {
+		return err
+	}
+	return db.WriteAssignments(rp.worksheet, converter.MapRoutesToDatabase(rp.rm, rp.lr))
+}
 
-	return &RoutingProcess{
-		rm: RouteManager,
-		ae: &appEvent,
-		lr: &lr,
-	}, nil
+// WriteRoutesSheet adds a Routes tab with one block per vehicle and returns
+// its title.
+func (rp *RoutingProcess) WriteRoutesSheet() (string, error) {
+	db, err := rp.openSourceSpreadsheet()
+	if err != nil {
+		return "", err
+	}
+	return db.WriteRoutesSheet(time.Now(), converter.MapRoutesToDatabase(rp.rm, rp.lr))
+}
 
+// reportDispatch tells the coordinator about guests the routing could not
+// place or routes that fail validation. It returns false when there was
+// nothing to report.
+func (cfg *Config) reportDispatch(rp *RoutingProcess) bool {
+	if rp.dispatchErr == nil {
+		return false
+	}
+	cfg.ErrorLog.Printf("dispatch: %v", rp.dispatchErr)
+	message := rp.dispatchErr.Error()
+	if len(rp.rm.Unassigned) > 0 {
+		message += ". Drag them from the Unassigned column into a vehicle."
+	}
+	title := "Routing Incomplete"
+	var invalid *app.ValidationError
+	if errors.As(rp.dispatchErr, &invalid) {
+		title = "Routes Inconsistent"
+	}
+	ShowErrorNotification(cfg.MainWindow, title, message)
+	return true
 }
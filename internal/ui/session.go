@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"github.com/andrew-tawfik/outreach-routing/internal/app"
+)
+
+// sessionExt is the extension of saved routing sessions, which are JSON.
+const sessionExt = ".outreach"
+
+// session captures the routing process, including manual edits, for saving.
+func (rp *RoutingProcess) session() app.Session {
+	return app.Session{
+		Event:            *rp.ae,
+		LocationRegistry: *rp.lr,
+		Routes:           rp.rm,
+		Source:           rp.source,
+		Worksheet:        rp.worksheet,
+		SavedAt:          time.Now(),
+	}
+}
+
+func sessionProcess(s app.Session) *RoutingProcess {
+	return &RoutingProcess{
+		rm:        s.Routes,
+		ae:        &s.Event,
+		lr:        &s.LocationRegistry,
+		source:    s.Source,
+		worksheet: s.Worksheet,
+
+		dispatchErr: s.DispatchErr,
+	}
+}
+
+func (cfg *Config) saveSession() {
+	rp, ok := cfg.currentRoutes()
+	if !ok {
+		return
+	}
+	s := rp.session()
+	cfg.saveExport("Save Session", rp.exportTitle(), sessionExt, func(w io.Writer) error {
+		return app.SaveSession(w, s)
+	})
+}
+
+// openSession loads a saved session and shows it as if it had just been
+// routed, with its manual edits in place.
+func (cfg *Config) openSession() {
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			ShowErrorNotification(cfg.MainWindow, "Open Session", err.Error())
+			return
+		}
+		if reader == nil {
+			return
+		}
+		defer reader.Close()
+
+		s, err := app.LoadSession(reader)
+		if err != nil {
+			cfg.ErrorLog.Printf("open session %s: %v", reader.URI().Name(), err)
+			ShowErrorNotification(cfg.MainWindow, "Open Session", err.Error())
+			return
+		}
+
+		rp := sessionProcess(s)
+		cfg.showResult(rp)
+		cfg.InfoLog.Printf("Opened session %s saved %s (%d manual edits)",
+			reader.URI().Name(), s.SavedAt.Format("2006-01-02 15:04"), len(s.Routes.Edits))
+		if !cfg.reportDispatch(rp) {
+			dialog.ShowInformation("Open Session",
+				fmt.Sprintf("Opened %s with %d vehicles.", reader.URI().Name(), len(s.Routes.Vehicles)), cfg.MainWindow)
+		}
+	}, cfg.MainWindow)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{sessionExt}))
+	open.Show()
+}
@@ -1,36 +1,154 @@
 package ui
 
 import (
+	"fmt"
 	"image/color"
 	"math"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	"github.com/andrew-tawfik/outreach-routing/internal/database"
 )
 
 func (cfg *Config) MakeUI() {
-	
+	cfg.forgetSavedSecrets()
 
 	var wrapper *mainContentWrapper
 
 	urlEntry := widget.NewEntry()
-	urlEntry.SetPlaceHolder("https://docs.google.com/spreadsheets/d/...")
+	urlEntry.SetPlaceHolder("https://docs.google.com/spreadsheets/d/... or a .csv/.xlsx file")
+
+	worksheetSelect := widget.NewSelect(nil, nil)
+	worksheetSelect.PlaceHolder = "Most recent tab"
+	worksheetSelect.Disable()
+
+	eventTypeSelect := widget.NewSelect(append([]string{autoEventType}, database.EventTypes...), nil)
+	eventTypeSelect.SetSelected(autoEventType)
+
+	var loadedSource string
+	urlEntry.OnChanged = func(text string) {
+		key := sourceKey(text)
+		if key == "" || key == loadedSource {
+			return
+		}
+		loadedSource = key
+		worksheetSelect.Options = nil
+		worksheetSelect.ClearSelected()
+		worksheetSelect.PlaceHolder = "Loading tabs..."
+		worksheetSelect.Disable()
+
+		go func() {
+			source, err := OpenGuestSource(text)
+
+			fyne.Do(func() {
+				if key != loadedSource {
+					return
+				}
+				worksheetSelect.PlaceHolder = "Most recent tab"
+				if err != nil {
+					worksheetSelect.Refresh()
+					cfg.ErrorLog.Printf("could not list worksheets: %v", err)
+					return
+				}
+				worksheetSelect.Options = source.Worksheets()
+				worksheetSelect.Enable()
+				worksheetSelect.SetSelected(source.LatestWorksheet())
+			})
+		}()
+	}
 
 	outputEntry := widget.NewMultiLineEntry()
 	outputEntry.SetText("…your output here…")
 	outputEntry.Wrapping = fyne.TextWrapWord
 
+	cfg.refreshOutput = func() {
+		if cfg.Rp != nil {
+			outputEntry.SetText(cfg.Rp.String())
+		}
+	}
+
 	var currentGrid *VehicleGrid 
 	var tabs *container.AppTabs  
 	var mapView *MapView
 
+	var showResult func(result *RoutingProcess)
+	showResult = func(result *RoutingProcess) {
+		cfg.Rp = result
+		outputEntry.SetText(result.String())
+		currentGrid = NewVehicleGrid(result.rm, cfg)
+		cfg.VehicleSection.Objects = []fyne.CanvasObject{currentGrid}
+		cfg.VehicleSection.Refresh()
+
+		if wrapper != nil {
+			wrapper.grid = currentGrid
+		}
+
+		mapView = NewMapView(cfg.Rp, cfg)
+
+		if tabs != nil {
+			tabs.Items[2].Content = mapView
+			tabs.Items[3].Text = attentionTabTitle(result)
+			tabs.Items[3].Content = NewAttentionPanel(cfg, func() {
+				showResult(cfg.Rp)
+			})
+			tabs.Refresh()
+		}
+	}
+
+	cfg.showResult = showResult
+
+	recordCheck := widget.NewCheck("Record this run", nil)
+	baselines := newBaselinePicker(cfg)
+
+	routeEvent := func(event *database.Event) {
+		popup := ShowMessage(cfg.MainWindow)
+		popup.Show()
+		record := recordCheck.Checked
+		prev := baselines.baseline()
+
+		go func() {
+			var result *RoutingProcess
+			var recorded *recordedEvent
+			var processErr error
+			if record {
+				result, recorded, processErr = RecordRouteEvent(event, prev)
+			} else {
+				result, processErr = RouteEvent(event, prev, 0)
+			}
+
+			fyne.Do(func() {
+				popup.Hide()
+				if recorded != nil {
+					cfg.saveRecording(recorded)
+				}
+
+				if processErr != nil {
+					ShowErrorNotification(cfg.MainWindow, "Processing Error", processErr.Error())
+					return
+				}
+				showResult(result)
+				cfg.recordHistory(result)
+				if !cfg.reportDispatch(result) {
+					ShowSuccess(cfg.MainWindow)
+				}
+			})
+		}()
+	}
+
 	runButton := widget.NewButton("Run", func() {
 		var popup *widget.PopUp
-		var result *RoutingProcess = nil
-		var processErr error
+		url := urlEntry.Text
+		worksheet := worksheetSelect.Selected
+		eventType := eventTypeSelect.Selected
+		if eventType == autoEventType {
+			eventType = ""
+		}
 
 		
 		fyne.Do(func() {
@@ -40,45 +158,34 @@ func (cfg *Config) MakeUI() {
 
 		
 		go func() {
-			
-			
-			result, processErr = ProcessEvent(urlEntry.Text)
+			event, loadErr := LoadEvent(url, worksheet, eventType)
 
-			
 			fyne.Do(func() {
-				
 				if popup != nil {
 					popup.Hide()
 				}
 
-				
-				if processErr != nil {
-					fyne.Do(func() {
-						ShowErrorNotification(cfg.MainWindow, "Processing Error", processErr.Error())
-					})
+				if loadErr != nil {
+					ShowErrorNotification(cfg.MainWindow, "Processing Error", loadErr.Error())
 					return
-				} else {
-					fyne.Do(func() {
-						ShowSuccess(cfg.MainWindow)
-					})
-				}
-				
-				cfg.Rp = result
-				outputEntry.SetText(result.String())
-				currentGrid = NewVehicleGrid(result.rm, cfg)
-				cfg.VehicleSection.Objects = []fyne.CanvasObject{currentGrid}
-				cfg.VehicleSection.Refresh()
-
-				if wrapper != nil {
-					wrapper.grid = currentGrid
 				}
 
-				mapView = NewMapView(cfg.Rp, cfg)
+				mergeAndRoute := func() {
+					if len(event.Duplicates) > 0 {
+						ShowDuplicateMerge(cfg.MainWindow, event, func() {
+							routeEvent(event)
+						})
+						return
+					}
+					routeEvent(event)
+				}
 
-				if tabs != nil {
-					tabs.Items[2].Content = mapView
-					tabs.Refresh()
+				if event.Report.HasIssues() {
+					cfg.InfoLog.Printf("Import report: %s\n%s", event.Report.GetSummary(), event.Report.GetDetails())
+					ShowImportReport(cfg.MainWindow, &event.Report, mergeAndRoute)
+					return
 				}
+				mergeAndRoute()
 			})
 		}()
 	})
@@ -87,13 +194,36 @@ func (cfg *Config) MakeUI() {
 	spacer := canvas.NewRectangle(color.Transparent)
 	spacer.SetMinSize(fyne.NewSize(500, 1)) 
 
+	openFileButton := widget.NewButtonWithIcon("Open File…", theme.FolderOpenIcon(), func() {
+		fileDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				ShowErrorNotification(cfg.MainWindow, "Open File Error", err.Error())
+				return
+			}
+			if reader == nil {
+				return
+			}
+			defer reader.Close()
+			urlEntry.SetText(reader.URI().Path())
+		}, cfg.MainWindow)
+		fileDialog.SetFilter(storage.NewExtensionFileFilter([]string{".csv", ".xlsx"}))
+		fileDialog.Show()
+	})
+
 	rButton := container.NewHBox(
+		openFileButton,
 		runButton,
+		recordCheck,
 		spacer,
 	)
 
-	urlCard := widget.NewCard("Insert Google Sheet URL", "", container.NewVBox(
+	urlCard := widget.NewCard("Insert Google Sheet URL or Open a CSV/Excel File", "", container.NewVBox(
 		container.NewBorder(nil, nil, nil, rButton, urlEntry),
+		container.NewGridWithColumns(2,
+			widget.NewForm(widget.NewFormItem("Worksheet", worksheetSelect)),
+			widget.NewForm(widget.NewFormItem("Event type", eventTypeSelect)),
+		),
+		widget.NewForm(widget.NewFormItem("Keep drivers from", baselines.sel)),
 	))
 
 	
@@ -130,6 +260,7 @@ func (cfg *Config) MakeUI() {
 					outputEntry.SetText(cfg.Rp.String())
 					outputEntry.Refresh()
 					cfg.InfoLog.Println("Changes submitted")
+					cfg.recordHistory(cfg.Rp)
 				}
 
 				mapView = NewMapView(cfg.Rp, cfg)
@@ -179,6 +310,12 @@ func (cfg *Config) MakeUI() {
 
 	routePlanningTab := container.NewMax(gradient, routePlanningContent)
 
+	openFromHistory := func(rp *RoutingProcess) {
+		showResult(rp)
+		tabs.SelectIndex(1)
+		cfg.reportDispatch(rp)
+	}
+
 	mapTabPlaceholder := container.NewCenter(
 		widget.NewLabel("Run the routing process to see the map visualization"),
 	)
@@ -188,8 +325,18 @@ func (cfg *Config) MakeUI() {
 		container.NewTabItem("Home", homeTab),
 		container.NewTabItem("Route Planning", routePlanningTab),
 		container.NewTabItem("Map", mapTabPlaceholder),
+		container.NewTabItem("Needs Attention", container.NewCenter(
+			widget.NewLabel("Guests that could not be located will be listed here"),
+		)),
+		container.NewTabItem("History", NewHistoryPanel(cfg, openFromHistory)),
 	)
 
+	cfg.refreshHistory = func() {
+		tabs.Items[4].Content = NewHistoryPanel(cfg, openFromHistory)
+		tabs.Refresh()
+		baselines.refresh()
+	}
+
 	
 	wrapper = &mainContentWrapper{
 		content: tabs,
@@ -199,6 +346,7 @@ func (cfg *Config) MakeUI() {
 
 	
 	cfg.MainWindow.SetContent(wrapper)
+	cfg.MainWindow.SetMainMenu(cfg.makeMainMenu())
 
 	
 	cfg.MainWindow.Canvas().SetOnTypedKey(func(key *fyne.KeyEvent) {
@@ -271,3 +419,13 @@ func (r *mainContentRenderer) Objects() []fyne.CanvasObject {
 }
 
 func (r *mainContentRenderer) Destroy() {}
+
+// autoEventType infers the event type from the worksheet title.
+const autoEventType = "Auto-detect"
+
+func attentionTabTitle(rp *RoutingProcess) string {
+	if rp == nil || !rp.ae.ApiErrors.HasErrors() {
+		return "Needs Attention"
+	}
+	return fmt.Sprintf("Needs Attention (%d)", len(rp.ae.ApiErrors.FailedGuests))
+}
@@ -7,6 +7,7 @@ import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 	"github.com/andrew-tawfik/outreach-routing/internal/app"
 )
@@ -47,6 +48,9 @@ func NewVehicleCard(index int, vehicle *app.Vehicle, grid *VehicleGrid) *Vehicle
 func (vc *VehicleCard) createTiles() {
 	guestCount := len(vc.vehicle.Guests)
 	totalTiles := guestCount + extraTiles
+	if vc.index == app.NoVehicle {
+		totalTiles = guestCount
+	}
 
 	vc.tiles = make([]*GuestTile, totalTiles)
 
@@ -65,7 +69,13 @@ func (vc *VehicleCard) createTiles() {
 
 
 func (vc *VehicleCard) CreateCard() fyne.CanvasObject {
+	if vc.index == app.NoVehicle {
+		return vc.createUnassignedCard()
+	}
 	title := fmt.Sprintf("Vehicle %d", vc.index+1)
+	if vc.vehicle.Driver != "" {
+		title += " · " + vc.vehicle.Driver
+	}
 
 	
 	background := canvas.NewRectangle(color.NRGBA{40, 40, 45, 255})
@@ -79,8 +89,13 @@ func (vc *VehicleCard) CreateCard() fyne.CanvasObject {
 	vc.tileGrid = vc.createTileGrid()
 
 	
+	navButton := widget.NewButtonWithIcon("Navigate", theme.NavigateNextIcon(), func() {
+		vc.grid.config.showNavigationLinks(vc.index, vc.vehicle)
+	})
+	navButton.Importance = widget.LowImportance
+
 	content := container.NewVBox(
-		titleLabel,
+		container.NewBorder(nil, nil, nil, navButton, titleLabel),
 		widget.NewSeparator(),
 		vc.tileGrid,
 	)
@@ -104,6 +119,30 @@ func (vc *VehicleCard) CreateCard() fyne.CanvasObject {
 	return vc.card
 }
 
+// createUnassignedCard shows the guests no vehicle picked up, to be dragged
+// into a vehicle by hand.
+func (vc *VehicleCard) createUnassignedCard() fyne.CanvasObject {
+	background := canvas.NewRectangle(color.NRGBA{90, 35, 35, 255})
+	background.CornerRadius = 12
+
+	people := 0
+	for _, g := range vc.vehicle.Guests {
+		people += g.GroupSize
+	}
+	titleLabel := widget.NewLabel(fmt.Sprintf("Unassigned · %d people", people))
+	titleLabel.TextStyle = fyne.TextStyle{Bold: true}
+
+	vc.tileGrid = vc.createTileGrid()
+	content := container.NewVBox(titleLabel, widget.NewSeparator(), vc.tileGrid)
+
+	spacer := canvas.NewRectangle(color.Transparent)
+	spacer.SetMinSize(fyne.NewSize(5, 5))
+
+	vc.card = container.NewBorder(spacer, spacer, spacer, spacer,
+		container.NewMax(background, container.NewPadded(content)))
+	return vc.card
+}
+
 
 func (vc *VehicleCard) createTileGrid() *fyne.Container {
 	tiles := make([]fyne.CanvasObject, len(vc.tiles))
@@ -157,8 +196,11 @@ func (vc *VehicleCard) IsTileEmpty(tileIndex int) bool {
 }
 
 
+// HasCapacityForGuest reports whether the guest being dragged fits the
+// seats left in this vehicle, with their household unless Shift splits it.
 func (vc *VehicleCard) HasCapacityForGuest(guest *app.Guest) bool {
-	return true
+	seats, _ := vc.grid.moveSeats(guest, vc.grid.dragOrigin.VehicleIndex)
+	return vc.vehicle.SeatsRemaining >= seats
 }
 
 
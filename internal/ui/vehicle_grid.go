@@ -20,6 +20,8 @@ type VehicleGrid struct {
 
 	
 	vehicles       []*VehicleCard
+	unassigned     *VehicleCard
+	pool           app.Vehicle
 	routeManager   *app.RouteManager
 	config         *Config
 	vehicleManager *VehicleManager
@@ -29,6 +31,7 @@ type VehicleGrid struct {
 	dragVisual   *fyne.Container 
 	draggedGuest *app.Guest      
 	dragOrigin   VehiclePosition 
+	dragHidden   []int
 	isDragging   bool
 
 	dragPosition    fyne.Position 
@@ -119,7 +122,7 @@ func (r *vehicleGridRenderer) Destroy() {}
 func (vg *VehicleGrid) createVehicleCards() *fyne.Container {
 	cards := make([]fyne.CanvasObject, 0, len(vg.vehicles))
 
-	for _, vehicleCard := range vg.vehicles {
+	for _, vehicleCard := range vg.cards() {
 		cards = append(cards, vehicleCard.CreateCard())
 	}
 
@@ -134,6 +137,32 @@ func (vg *VehicleGrid) refreshVehicles() {
 	for i := range vg.routeManager.Vehicles {
 		vg.vehicles[i] = NewVehicleCard(i, &vg.routeManager.Vehicles[i], vg)
 	}
+	vg.pool.Guests = vg.routeManager.Unassigned
+	vg.unassigned = NewVehicleCard(app.NoVehicle, &vg.pool, vg)
+}
+
+// cards lists the cards shown on the grid: the Unassigned column while any
+// guest is waiting for a vehicle, then the vehicles.
+func (vg *VehicleGrid) cards() []*VehicleCard {
+	if len(vg.routeManager.Unassigned) == 0 {
+		return vg.vehicles
+	}
+	return append([]*VehicleCard{vg.unassigned}, vg.vehicles...)
+}
+
+// allCards returns every card, including the Unassigned column while it
+// is hidden, so a drag that emptied it leaves no highlight behind.
+func (vg *VehicleGrid) allCards() []*VehicleCard {
+	return append([]*VehicleCard{vg.unassigned}, vg.vehicles...)
+}
+
+// card returns the card of vehicle i, or the Unassigned column for
+// app.NoVehicle.
+func (vg *VehicleGrid) card(i int) *VehicleCard {
+	if i == app.NoVehicle {
+		return vg.unassigned
+	}
+	return vg.vehicles[i]
 }
 
 
@@ -146,23 +175,48 @@ func (vg *VehicleGrid) StartDrag(guest *app.Guest, origin VehiclePosition, start
 	vg.dragPosition = startPos
 
 	
-	vg.createDragVisual(guest)
+	vg.dragHidden = vg.householdTiles(origin)
+	vg.createDragVisual(guest, len(vg.dragHidden)-1)
 
 	
-	vg.vehicles[origin.VehicleIndex].HideGuest(origin.TileIndex)
+	for _, tile := range vg.dragHidden {
+		vg.card(origin.VehicleIndex).HideGuest(tile)
+	}
 
 	
 	vg.dragOverlay.Hide()
 }
 
 
-func (vg *VehicleGrid) createDragVisual(guest *app.Guest) {
+// householdTiles returns the tiles of the guest at origin and of the rest
+// of their household, which are dragged along with them.
+func (vg *VehicleGrid) householdTiles(origin VehiclePosition) []int {
+	vehicle := vg.card(origin.VehicleIndex).vehicle
+	if origin.TileIndex >= len(vehicle.Guests) {
+		return []int{origin.TileIndex}
+	}
+	coord := vehicle.Guests[origin.TileIndex].Coordinates
+
+	tiles := []int{origin.TileIndex}
+	for i, g := range vehicle.Guests {
+		if i != origin.TileIndex && g.Coordinates == coord {
+			tiles = append(tiles, i)
+		}
+	}
+	return tiles
+}
+
+func (vg *VehicleGrid) createDragVisual(guest *app.Guest, others int) {
 	
 	background := canvas.NewRectangle(color.NRGBA{60, 60, 70, 255}) 
 	background.CornerRadius = 3
 	background.Resize(fyne.NewSize(190, 40))
 
-	nameLabel := widget.NewLabel(guest.Name + " (" + fmt.Sprintf("%d", guest.GroupSize) + ")")
+	text := guest.Name + " (" + guest.SizeLabel() + ")"
+	if others > 0 {
+		text += fmt.Sprintf(" +%d", others)
+	}
+	nameLabel := widget.NewLabel(text)
 	nameLabel.TextStyle = fyne.TextStyle{Bold: true}
 
 	vg.dragVisual = container.NewMax(background, container.NewPadded(nameLabel))
@@ -210,8 +264,7 @@ func (vg *VehicleGrid) EndDrag(globalPos fyne.Position) {
 	if vg.isValidDropTarget(targetPos) {
 		vg.performMove(vg.dragOrigin, targetPos)
 	} else {
-		
-		vg.vehicles[vg.dragOrigin.VehicleIndex].ShowGuest(vg.dragOrigin.TileIndex)
+		vg.showDragHidden()
 	}
 
 	
@@ -221,22 +274,29 @@ func (vg *VehicleGrid) EndDrag(globalPos fyne.Position) {
 
 func (vg *VehicleGrid) CancelDrag() {
 	if vg.isDragging {
-		
-		vg.vehicles[vg.dragOrigin.VehicleIndex].ShowGuest(vg.dragOrigin.TileIndex)
+		vg.showDragHidden()
 		vg.cleanupDrag()
 	}
 }
 
+// showDragHidden shows the tiles hidden while dragging again.
+func (vg *VehicleGrid) showDragHidden() {
+	for _, tile := range vg.dragHidden {
+		vg.card(vg.dragOrigin.VehicleIndex).ShowGuest(tile)
+	}
+}
+
 
 func (vg *VehicleGrid) cleanupDrag() {
 	vg.isDragging = false
 	vg.draggedGuest = nil
+	vg.dragHidden = nil
 	vg.dragOverlay.Hide()
 	vg.dragOverlay.Objects = nil
 	vg.dragVisual = nil
 
 	
-	for _, vehicle := range vg.vehicles {
+	for _, vehicle := range vg.allCards() {
 		vehicle.RemoveAllHighlights()
 	}
 }
@@ -250,9 +310,9 @@ func (vg *VehicleGrid) positionToTile() VehiclePosition {
 func (vg *VehicleGrid) highlightValidDropTargets() {
 	targetPos := vg.positionToTile()
 
-	for vIndex, vehicle := range vg.vehicles {
+	for _, vehicle := range vg.allCards() {
 		for tIndex, tile := range vehicle.tiles {
-			if vIndex == targetPos.VehicleIndex && tIndex == targetPos.TileIndex &&
+			if vehicle.index == targetPos.VehicleIndex && tIndex == targetPos.TileIndex &&
 				vg.isValidDropTarget(targetPos) {
 				tile.HighlightAsDropTarget()
 			} else {
@@ -288,125 +348,83 @@ func (vg *VehicleGrid) isValidDropTarget(target VehiclePosition) bool {
 	}
 
 	
-	return vehicle.IsTileEmpty(target.TileIndex)
+	return vehicle.IsTileEmpty(target.TileIndex) && vehicle.HasCapacityForGuest(vg.draggedGuest)
 }
 
+// moveSeats returns how many seats guest, moved out of vehicle from, takes
+// in another vehicle: their whole household, or only their own group when
+// Shift splits a household too large for one vehicle. It also reports
+// whether the move splits.
+func (vg *VehicleGrid) moveSeats(guest *app.Guest, from int) (int, bool) {
+	vehicle := vg.card(from).vehicle
+	guestIndex := vg.findGuestIndex(vehicle, guest)
+	if guestIndex < 0 {
+		return 0, false
+	}
+	household := vehicle.HouseholdOf(guestIndex)
+	if splitRequested() && !household.FitsOneVehicle() {
+		return vehicle.Guests[guestIndex].GroupSize, true
+	}
+	return household.People, false
+}
+
+// performMove drops the dragged guest at to. The guest's household moves
+// with them; holding Shift splits off just the dragged guest when the
+// household is too large for one vehicle. Guests can be dragged out of the
+// Unassigned column but not back into it.
 func (vg *VehicleGrid) performMove(from, to VehiclePosition) {
 	rm := vg.config.Rp.rm
 	lr := vg.config.Rp.lr
 
-	
-	if from.VehicleIndex == to.VehicleIndex {
-		vehicle := &rm.Vehicles[from.VehicleIndex]
-
-		
-		guestIndex := vg.findGuestIndex(vehicle, vg.draggedGuest)
-		if guestIndex < 0 {
-			return
-		}
-
-		
-		guest := vehicle.Guests[guestIndex]
-
-		
-		insertPos := to.TileIndex
-		if insertPos > len(vehicle.Guests) {
-			insertPos = len(vehicle.Guests)
-		}
-
-		
-		
-		if insertPos > guestIndex {
-			insertPos--
-		}
-
-		
-		vehicle.Guests = append(
-			vehicle.Guests[:guestIndex],
-			vehicle.Guests[guestIndex+1:]...,
-		)
-
-		
-		vehicle.Guests = append(
-			vehicle.Guests[:insertPos],
-			append([]app.Guest{guest}, vehicle.Guests[insertPos:]...)...,
-		)
-
-		
-		vehicle.UpdateRouteFromGuests(lr, vg.eventType)
-
-		
-		vg.refreshAfterMove()
+	_, split := vg.moveSeats(vg.draggedGuest, from.VehicleIndex)
+	moved, err := rm.MoveGuest(vg.draggedGuest, from.VehicleIndex, to.VehicleIndex, to.TileIndex, split, lr, vg.eventType)
+	if err != nil {
+		vg.showDragHidden()
+		ShowErrorNotification(vg.config.MainWindow, "Move Guest", err.Error())
 		return
 	}
-
-	
-	sourceVehicle := &rm.Vehicles[from.VehicleIndex]
-	guestIndex := vg.findGuestIndex(sourceVehicle, vg.draggedGuest)
-	guest := sourceVehicle.Guests[guestIndex]
-
-	if guestIndex >= 0 {
-		
-		sourceVehicle.Guests = append(
-			sourceVehicle.Guests[:guestIndex],
-			sourceVehicle.Guests[guestIndex+1:]...,
-		)
-		sourceVehicle.SeatsRemaining += vg.draggedGuest.GroupSize
-
-		
-		sourceVehicle.UpdateRouteFromGuests(lr, vg.eventType)
+	if moved == 0 {
+		return
 	}
 
-	
-	targetVehicle := &rm.Vehicles[to.VehicleIndex]
-
-	
-	insertPos := to.TileIndex
-	if insertPos > len(targetVehicle.Guests) {
-		insertPos = len(targetVehicle.Guests)
+	if from.VehicleIndex != to.VehicleIndex {
+		vg.vehicleManager.hasChanges = true
 	}
-
-	
-	targetVehicle.Guests = append(targetVehicle.Guests[:insertPos],
-		append([]app.Guest{guest}, targetVehicle.Guests[insertPos:]...)...)
-
-	targetVehicle.SeatsRemaining -= guest.GroupSize
-
-	
-	targetVehicle.UpdateRouteFromGuests(lr, vg.eventType)
-
-	
-	vg.vehicleManager.hasChanges = true
-
-	
 	vg.refreshAfterMove()
+	vg.config.checkRoutes("Move Guest")
+}
+
+// splitRequested reports whether Shift is held, asking to split a
+// household instead of moving it as a unit.
+func splitRequested() bool {
+	if d, ok := fyne.CurrentApp().Driver().(desktop.Driver); ok {
+		return d.CurrentKeyModifiers()&fyne.KeyModifierShift != 0
+	}
+	return false
 }
 
 
 func (vg *VehicleGrid) findGuestIndex(vehicle *app.Vehicle, guest *app.Guest) int {
-	for i, g := range vehicle.Guests {
-		if g.Name == guest.Name && g.Address == guest.Address {
-			return i
-		}
-	}
-	return -1
+	return vehicle.GuestIndex(guest)
 }
 
 
 func (vg *VehicleGrid) refreshAfterMove() {
 	
-	for _, vehicle := range vg.vehicles {
+	for _, vehicle := range vg.allCards() {
 		vehicle.RemoveAllHighlights()
 	}
 
 	
+	vg.pool.Guests = vg.routeManager.Unassigned
+	vg.unassigned.RefreshTiles()
 	for i := range vg.vehicles {
 		vg.vehicles[i].RefreshTiles()
 	}
 
 	
 	vg.gridContainer.Objects = nil
-	for _, vehicleCard := range vg.vehicles {
+	for _, vehicleCard := range vg.cards() {
 		vg.gridContainer.Objects = append(vg.gridContainer.Objects, vehicleCard.CreateCard())
 	}
 	vg.gridContainer.Refresh()
@@ -15,6 +15,9 @@ type VehicleManager struct {
 	initialGuestState    map[int][]app.Guest
 	initialRouteState    map[int]app.Route
 	initialLocationState map[int][]coordinates.GuestCoordinates
+	initialUnassigned    []app.Guest
+	initialServed        map[int]int
+	initialEditCount     int
 	hasChanges           bool
 }
 
@@ -57,6 +60,12 @@ func (vm *VehicleManager) captureInitialState() {
 
 		vm.initialRouteState[i] = routeCopy
 	}
+	vm.initialUnassigned = append([]app.Guest(nil), vm.routeManager.Unassigned...)
+	vm.initialServed = make(map[int]int, len(vm.routeManager.ServedDestinations))
+	for idx, vi := range vm.routeManager.ServedDestinations {
+		vm.initialServed[idx] = vi
+	}
+	vm.initialEditCount = len(vm.routeManager.Edits)
 	vm.hasChanges = false
 }
 
@@ -71,26 +80,18 @@ func (vm *VehicleManager) MoveGuest(guest *app.Guest, fromVehicle, toVehicle int
 	sourceVehicle := &rm.Vehicles[fromVehicle]
 	targetVehicle := &rm.Vehicles[toVehicle]
 
-	if targetVehicle.SeatsRemaining < guest.GroupSize {
-		return NewVehicleError("insufficient capacity in target vehicle")
-	}
-
 	guestIndex := vm.findGuestIndex(sourceVehicle, guest)
 	if guestIndex < 0 {
 		return NewVehicleError("guest not found in source vehicle")
 	}
 
-	sourceVehicle.Guests = append(
-		sourceVehicle.Guests[:guestIndex],
-		sourceVehicle.Guests[guestIndex+1:]...,
-	)
-	sourceVehicle.SeatsRemaining += guest.GroupSize
-
-	targetVehicle.Guests = append(targetVehicle.Guests, *guest)
-	targetVehicle.SeatsRemaining -= guest.GroupSize
+	if targetVehicle.SeatsRemaining < sourceVehicle.HouseholdOf(guestIndex).People {
+		return NewVehicleError("insufficient capacity in target vehicle")
+	}
 
-	vm.updateVehicleRoute(fromVehicle)
-	vm.updateVehicleRoute(toVehicle)
+	if _, err := rm.MoveGuest(guest, fromVehicle, toVehicle, len(targetVehicle.Guests), false, vm.config.Rp.lr, vm.config.Rp.ae.EventType); err != nil {
+		return NewVehicleError(err.Error())
+	}
 
 	vm.hasChanges = true
 	return nil
@@ -139,6 +140,14 @@ func (vm *VehicleManager) ResetToInitialState() {
 		}
 	}
 
+	vm.routeManager.Unassigned = append([]app.Guest(nil), vm.initialUnassigned...)
+	for idx, vi := range vm.initialServed {
+		vm.routeManager.ServedDestinations[idx] = vi
+	}
+
+	if vm.initialEditCount <= len(vm.routeManager.Edits) {
+		vm.routeManager.Edits = vm.routeManager.Edits[:vm.initialEditCount]
+	}
 	vm.hasChanges = false
 
 	vm.grid.refreshAfterMove()
@@ -224,11 +233,12 @@ func (vm *VehicleManager) ValidateMove(guest *app.Guest, fromVehicle, toVehicle
 	sourceVehicle := &vm.routeManager.Vehicles[fromVehicle]
 	targetVehicle := &vm.routeManager.Vehicles[toVehicle]
 
-	if vm.findGuestIndex(sourceVehicle, guest) < 0 {
+	guestIndex := vm.findGuestIndex(sourceVehicle, guest)
+	if guestIndex < 0 {
 		return NewVehicleError("guest not found in source vehicle")
 	}
 
-	if targetVehicle.SeatsRemaining < guest.GroupSize {
+	if targetVehicle.SeatsRemaining < sourceVehicle.HouseholdOf(guestIndex).People {
 		return NewVehicleError("insufficient capacity in target vehicle")
 	}
 
@@ -236,12 +246,7 @@ func (vm *VehicleManager) ValidateMove(guest *app.Guest, fromVehicle, toVehicle
 }
 
 func (vm *VehicleManager) findGuestIndex(vehicle *app.Vehicle, guest *app.Guest) int {
-	for i, g := range vehicle.Guests {
-		if g.Name == guest.Name && g.Address == guest.Address {
-			return i
-		}
-	}
-	return -1
+	return vehicle.GuestIndex(guest)
 }
 
 type VehicleCapacity struct {
@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"github.com/andrew-tawfik/outreach-routing/internal/app"
+	"github.com/andrew-tawfik/outreach-routing/internal/history"
+)
+
+const (
+	baselineNone = "Nobody (route afresh)"
+	baselineFile = "Saved session…"
+
+	// baselineEvents is how many recent events are offered to start from.
+	baselineEvents = 12
+)
+
+// baselinePicker chooses the earlier event whose drivers returning guests
+// keep, either from the history or from a saved session file.
+type baselinePicker struct {
+	cfg *Config
+	sel *widget.Select
+
+	events   map[string]history.Event
+	files    map[string]app.Baseline
+	selected string
+}
+
+func newBaselinePicker(cfg *Config) *baselinePicker {
+	bp := &baselinePicker{
+		cfg:      cfg,
+		files:    make(map[string]app.Baseline),
+		selected: baselineNone,
+	}
+	bp.sel = widget.NewSelect(nil, bp.onSelected)
+	bp.refresh()
+	return bp
+}
+
+// refresh lists the most recent events in the history.
+func (bp *baselinePicker) refresh() {
+	bp.events = make(map[string]history.Event)
+	options := []string{baselineNone}
+
+	if bp.cfg.History != nil {
+		events, err := bp.cfg.History.Events()
+		if err != nil {
+			bp.cfg.ErrorLog.Printf("history: %v", err)
+		}
+		for i, ev := range events {
+			if i == baselineEvents {
+				break
+			}
+			title := ev.Worksheet
+			if title == "" {
+				title = ev.EventType
+			}
+			label := fmt.Sprintf("%s  %s", ev.Date.Format("2006-01-02"), title)
+			bp.events[label] = ev
+			options = append(options, label)
+		}
+	}
+	files := make([]string, 0, len(bp.files))
+	for label := range bp.files {
+		files = append(files, label)
+	}
+	sort.Strings(files)
+	options = append(options, files...)
+	options = append(options, baselineFile)
+
+	bp.sel.Options = options
+	if _, ok := bp.events[bp.selected]; !ok && bp.files[bp.selected] == nil {
+		bp.selected = baselineNone
+	}
+	bp.sel.SetSelected(bp.selected)
+}
+
+func (bp *baselinePicker) onSelected(option string) {
+	if option != baselineFile {
+		bp.selected = option
+		return
+	}
+
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			ShowErrorNotification(bp.cfg.MainWindow, "Keep Drivers", err.Error())
+		}
+		if err != nil || reader == nil {
+			bp.sel.SetSelected(bp.selected)
+			return
+		}
+		defer reader.Close()
+
+		s, err := app.LoadSession(reader)
+		if err != nil {
+			ShowErrorNotification(bp.cfg.MainWindow, "Keep Drivers", err.Error())
+			bp.sel.SetSelected(bp.selected)
+			return
+		}
+
+		label := "File: " + reader.URI().Name()
+		bp.files[label] = s.Routes.Assignments()
+		bp.selected = label
+		bp.refresh()
+	}, bp.cfg.MainWindow)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{sessionExt}))
+	open.Show()
+}
+
+// baseline returns the assignments of the chosen event, or nil to route
+// afresh.
+func (bp *baselinePicker) baseline() app.Baseline {
+	b := bp.files[bp.selected]
+	if ev, ok := bp.events[bp.selected]; ok {
+		b = ev.Baseline()
+	}
+	if len(b) == 0 {
+		return nil
+	}
+	return b
+}